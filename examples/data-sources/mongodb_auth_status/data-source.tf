@@ -0,0 +1,5 @@
+data "mongodb_auth_status" "example" {}
+
+output "manage_users" {
+  value = data.mongodb_auth_status.example.auth_enabled
+}
@@ -0,0 +1,4 @@
+data "mongodb_chunk_distribution" "example" {
+  database   = "test"
+  collection = "example"
+}
@@ -0,0 +1,17 @@
+data "mongodb_collection" "example" {
+  database = "shop"
+  name     = "orders"
+}
+
+# Index a collection created by an application
+resource "mongodb_index" "created_at" {
+  database   = data.mongodb_collection.example.database
+  collection = data.mongodb_collection.example.name
+  name       = "created_at"
+  keys = [
+    {
+      "field" : "created_at"
+      "type" : "asc"
+    }
+  ]
+}
@@ -0,0 +1,5 @@
+data "mongodb_connection_string" "example" {}
+
+output "mongodb_uri" {
+  value = data.mongodb_connection_string.example.uri
+}
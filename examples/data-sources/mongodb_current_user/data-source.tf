@@ -0,0 +1,4 @@
+data "mongodb_current_user" "example" {
+  database         = "app"
+  required_actions = ["createCollection", "createIndex"]
+}
@@ -0,0 +1,17 @@
+data "mongodb_database" "example" {
+  name = "shop"
+}
+
+# Index every existing collection of the database
+resource "mongodb_index" "created_at" {
+  for_each   = toset(data.mongodb_database.example.collections)
+  database   = data.mongodb_database.example.name
+  collection = each.value
+  name       = "created_at"
+  keys = [
+    {
+      "field" : "created_at"
+      "type" : "asc"
+    }
+  ]
+}
@@ -0,0 +1,9 @@
+# Databases without collections, e.g. to clean them up
+data "mongodb_databases" "empty" {
+  empty = true
+}
+
+# Databases larger than 1 GB
+data "mongodb_databases" "large" {
+  size_gte = 1073741824
+}
@@ -0,0 +1,9 @@
+data "mongodb_index" "example" {
+  database   = "test"
+  collection = "example"
+  name       = "email_unique"
+}
+
+output "email_unique_exists" {
+  value = data.mongodb_index.example.exists
+}
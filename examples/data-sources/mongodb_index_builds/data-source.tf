@@ -0,0 +1,8 @@
+data "mongodb_index_builds" "example" {
+  database   = "shop"
+  collection = "orders"
+}
+
+output "building" {
+  value = length(data.mongodb_index_builds.example.builds) > 0
+}
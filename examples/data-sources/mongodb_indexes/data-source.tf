@@ -0,0 +1,4 @@
+data "mongodb_indexes" "example" {
+  database   = "test"
+  collection = "example"
+}
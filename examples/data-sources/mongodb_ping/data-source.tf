@@ -0,0 +1,7 @@
+data "mongodb_ping" "example" {
+  timeout_seconds = 10
+}
+
+output "reachable" {
+  value = data.mongodb_ping.example.reachable
+}
@@ -0,0 +1,6 @@
+data "mongodb_query_plan" "example" {
+  database   = "test"
+  collection = "example"
+  query      = jsonencode({ status = "active" })
+  sort       = jsonencode({ created_at = -1 })
+}
@@ -0,0 +1,8 @@
+data "mongodb_shard_key" "example" {
+  database   = "shop"
+  collection = "orders"
+}
+
+output "orders_hashed_sharding" {
+  value = data.mongodb_shard_key.example.sharded && data.mongodb_shard_key.example.hashed
+}
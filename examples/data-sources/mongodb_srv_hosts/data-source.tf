@@ -0,0 +1,7 @@
+data "mongodb_srv_hosts" "example" {
+  srv_host = "cluster.example.net"
+}
+
+output "seed_hosts" {
+  value = data.mongodb_srv_hosts.example.hosts
+}
@@ -0,0 +1,5 @@
+data "mongodb_storage_stats" "example" {}
+
+output "cache_usage_percent" {
+  value = data.mongodb_storage_stats.example.cache_bytes * 100 / data.mongodb_storage_stats.example.cache_max_bytes
+}
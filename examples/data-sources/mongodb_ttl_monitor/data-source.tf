@@ -0,0 +1,5 @@
+data "mongodb_ttl_monitor" "example" {}
+
+output "max_expiration_delay_seconds" {
+  value = data.mongodb_ttl_monitor.example.ttl_monitor_sleep_seconds
+}
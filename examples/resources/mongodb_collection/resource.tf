@@ -2,3 +2,21 @@ resource "mongodb_collection" "example" {
   database = "test"
   name     = "example"
 }
+
+resource "mongodb_collection" "with_namespace" {
+  namespace = "test.with_namespace"
+}
+
+resource "mongodb_collection" "described" {
+  database    = "test"
+  name        = "orders"
+  description = "Orders of the shop"
+}
+
+resource "mongodb_collection" "capped" {
+  database      = "test"
+  name          = "logs"
+  capped        = true
+  size_in_bytes = 10485760
+  max_documents = 10000
+}
@@ -0,0 +1,5 @@
+resource "mongodb_collection_compact" "example" {
+  database   = "test"
+  collection = "example"
+  trigger    = "2024-01-01"
+}
@@ -0,0 +1,23 @@
+resource "mongodb_collection_index" "events" {
+  database   = "test"
+  collection = "events"
+  indexes = [
+    {
+      name = "kind_created_at"
+      keys = [
+        { field = "kind", type = "asc" },
+        { field = "created_at", type = "desc" },
+      ]
+    },
+    {
+      name   = "email"
+      keys   = [{ field = "email", type = "asc" }]
+      unique = true
+    },
+    {
+      name                 = "expiry"
+      keys                 = [{ field = "expires_at", type = "asc" }]
+      expire_after_seconds = 0
+    },
+  ]
+}
@@ -1,3 +1,12 @@
 resource "mongodb_database" "example" {
   name = "some-database-name"
 }
+
+resource "mongodb_database" "slow_drop" {
+  name = "some-large-database"
+
+  timeouts {
+    create = "5m"
+    delete = "30m"
+  }
+}
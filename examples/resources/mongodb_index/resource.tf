@@ -9,3 +9,68 @@ resource "mongodb_index" "example" {
     }
   ]
 }
+
+# Unique regardless of case, e.g. Foo and foo are duplicates
+resource "mongodb_index" "email" {
+  database   = "test"
+  collection = "users"
+  name       = "email_unique"
+  keys = [
+    {
+      "field" : "email"
+      "type" : "asc"
+    }
+  ]
+  unique = true
+  collation = {
+    locale   = "en"
+    strength = 2
+  }
+}
+
+# Unique among the active accounts only, the other accounts are not indexed
+resource "mongodb_index" "active_email" {
+  database   = "test"
+  collection = "accounts"
+  name       = "email_active"
+  keys = [
+    {
+      "field" : "email"
+      "type" : "asc"
+    }
+  ]
+  unique                    = true
+  partial_filter_expression = jsonencode({ "status" = "active" })
+}
+
+# Flat coordinates of a 1024x1024 grid
+resource "mongodb_index" "position" {
+  database   = "test"
+  collection = "tiles"
+  name       = "position"
+  keys = [
+    {
+      "field" : "position"
+      "type" : "2d"
+    }
+  ]
+  min = 0
+  max = 1024
+}
+
+# Fields of the attributes and tags sub-documents, whatever their names
+resource "mongodb_index" "attributes" {
+  database   = "test"
+  collection = "products"
+  name       = "attributes"
+  keys = [
+    {
+      "field" : "$**"
+      "type" : "asc"
+    }
+  ]
+  wildcard_projection = {
+    "attributes" = 1
+    "tags"       = 1
+  }
+}
@@ -0,0 +1,13 @@
+resource "mongodb_role" "orders_writer" {
+  database  = "shop"
+  role_name = "orders_writer"
+  privileges = [
+    {
+      resource = { db = "shop", collection = "orders" }
+      actions  = ["find", "insert", "update"]
+    },
+  ]
+  roles = [
+    { role = "read", db = "reporting" },
+  ]
+}
@@ -0,0 +1,28 @@
+variable "app_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "mongodb_user" "app" {
+  database = "shop"
+  username = "shop-app"
+  password = var.app_password
+  roles = [
+    { role = "readWrite", db = "shop" },
+    { role = "read", db = "reporting" },
+  ]
+}
+
+# Only authenticates with SCRAM-SHA-256, from the application subnet
+resource "mongodb_user" "restricted" {
+  database   = "shop"
+  username   = "shop-batch"
+  password   = var.app_password
+  mechanisms = ["SCRAM-SHA-256"]
+  authentication_restrictions = [
+    { client_source = ["10.0.1.0/24"] },
+  ]
+  roles = [
+    { role = "read", db = "shop" },
+  ]
+}
@@ -0,0 +1,14 @@
+resource "mongodb_collection" "orders" {
+  database = "test"
+  name     = "orders"
+}
+
+resource "mongodb_view" "active_orders" {
+  database = "test"
+  name     = "active_orders"
+  view_on  = mongodb_collection.orders.name
+  pipeline = jsonencode([
+    { "$match" = { "status" = "active" } },
+    { "$project" = { "status" = 0 } },
+  ])
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &authStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &authStatusDataSource{}
+)
+
+// authStatusDataSource is the data source implementation.
+type authStatusDataSource struct {
+	client *mongodbClient
+}
+
+// authStatusDataSourceModel maps the data source schema data.
+type authStatusDataSourceModel struct {
+	AuthEnabled   bool         `tfsdk:"auth_enabled"`
+	Authenticated bool         `tfsdk:"authenticated"`
+	Id            types.String `tfsdk:"id"`
+}
+
+// cmdLineOpts maps the security options returned by the getCmdLineOpts command.
+type cmdLineOpts struct {
+	Parsed struct {
+		Security struct {
+			Authorization string `bson:"authorization"`
+			KeyFile       string `bson:"keyFile"`
+		} `bson:"security"`
+	} `bson:"parsed"`
+}
+
+// NewAuthStatusDataSource is a helper function to simplify the provider implementation.
+func NewAuthStatusDataSource() datasource.DataSource {
+	return &authStatusDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *authStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB auth status data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB auth status data source")
+}
+
+// Metadata returns the data source type name.
+func (d *authStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *authStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read whether the server enforces authentication and whether the provider connection is authenticated.",
+		Attributes: map[string]schema.Attribute{
+			"auth_enabled": schema.BoolAttribute{
+				Description: "Whether the server enforces authentication, i.e. runs with authorization enabled or a key file.",
+				Computed:    true,
+			},
+			"authenticated": schema.BoolAttribute{
+				Description: "Whether the provider connection is authenticated.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *authStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state authStatusDataSourceModel
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	tflog.Debug(ctx, "Reading auth status")
+
+	var status struct {
+		AuthInfo struct {
+			AuthenticatedUsers []bson.Raw `bson:"authenticatedUsers"`
+		} `bson:"authInfo"`
+	}
+	err := d.client.Database("admin").RunCommand(ctx, bson.D{{Key: "connectionStatus", Value: 1}}).Decode(&status)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read connection status",
+			"An unexpected error occurred when reading connection status. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.Authenticated = len(status.AuthInfo.AuthenticatedUsers) > 0
+
+	state.AuthEnabled, err = authEnabled(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read command line options",
+			"An unexpected error occurred when reading command line options. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.Id = types.StringValue("to_be_ignored")
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Read auth status")
+}
+
+// Check whether the server enforces authentication. Users not granted getCmdLineOpts can't read the options,
+// which only happens when authentication is enforced.
+func authEnabled(ctx context.Context, client *mongodbClient) (bool, error) {
+	var opts cmdLineOpts
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "getCmdLineOpts", Value: 1}}).Decode(&opts)
+	if isUnauthorized(err) {
+		tflog.Debug(ctx, "Not authorized to read command line options, authentication is enforced")
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return opts.Parsed.Security.Authorization == "enabled" || opts.Parsed.Security.KeyFile != "", nil
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAuthStatusDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_auth_status" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_auth_status.test", "auth_enabled", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_auth_status.test", "authenticated", "true"),
+				),
+			},
+			// Without credentials getCmdLineOpts is not authorized, which is reported as authentication enforced.
+			{
+				Config: `
+provider "mongodb" {
+  host = "localhost"
+  port = "27017"
+}
+
+data "mongodb_auth_status" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_auth_status.test", "auth_enabled", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_auth_status.test", "authenticated", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &chunkDistributionDataSource{}
+	_ datasource.DataSourceWithConfigure = &chunkDistributionDataSource{}
+)
+
+// chunkDistributionDataSource is the data source implementation.
+type chunkDistributionDataSource struct {
+	client *mongodbClient
+}
+
+// chunkDistributionDataSourceModel maps the data source schema data.
+type chunkDistributionDataSourceModel struct {
+	Database    string       `tfsdk:"database"`
+	Collection  string       `tfsdk:"collection"`
+	Shards      []shardChunk `tfsdk:"shards"`
+	TotalChunks int64        `tfsdk:"total_chunks"`
+	Id          types.String `tfsdk:"id"`
+}
+
+type shardChunk struct {
+	Shard  string `tfsdk:"shard" bson:"_id"`
+	Chunks int64  `tfsdk:"chunks" bson:"chunks"`
+}
+
+// NewChunkDistributionDataSource is a helper function to simplify the provider implementation.
+func NewChunkDistributionDataSource() datasource.DataSource {
+	return &chunkDistributionDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *chunkDistributionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB chunk distribution data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB chunk distribution data source")
+}
+
+// Metadata returns the data source type name.
+func (d *chunkDistributionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_chunk_distribution"
+}
+
+// Schema defines the schema for the data source.
+func (d *chunkDistributionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read the distribution of the chunks of a sharded collection across shards.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database of the collection.",
+				Required:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "Name of the sharded collection.",
+				Required:    true,
+			},
+			"shards": schema.ListNestedAttribute{
+				Description: "Number of chunks on each shard.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"shard": schema.StringAttribute{
+							Description: "Name of the shard.",
+							Computed:    true,
+						},
+						"chunks": schema.Int64Attribute{
+							Description: "Number of chunks of the collection on the shard.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"total_chunks": schema.Int64Attribute{
+				Description: "Number of chunks of the collection.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *chunkDistributionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state chunkDistributionDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	namespace := fmt.Sprintf("%s.%s", state.Database, state.Collection)
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading chunk distribution of %s", namespace))
+
+	sharded, err := isShardedCluster(ctx, d.client.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to get server topology",
+			"An unexpected error occurred when getting server topology. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	if !sharded {
+		resp.Diagnostics.AddError(
+			"Not a sharded cluster",
+			"Chunk distribution can only be read when connected to a mongos of a sharded cluster.",
+		)
+		return
+	}
+
+	config := d.client.Database("config")
+
+	var shardedCollection struct {
+		Uuid primitive.Binary `bson:"uuid"`
+	}
+	err = config.Collection("collections").FindOne(ctx, bson.D{
+		{Key: "_id", Value: namespace},
+		{Key: "dropped", Value: bson.D{{Key: "$ne", Value: true}}},
+	}).Decode(&shardedCollection)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		resp.Diagnostics.AddError(
+			"Collection not sharded",
+			fmt.Sprintf("Collection %s is not sharded", namespace),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read sharded collection",
+			"An unexpected error occurred when reading sharded collection. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	// Chunks reference their collection by uuid since MongoDB 5.0, and by namespace before.
+	cursor, err := config.Collection("chunks").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "uuid", Value: shardedCollection.Uuid}},
+			bson.D{{Key: "ns", Value: namespace}},
+		}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$shard"},
+			{Key: "chunks", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	})
+	if err == nil {
+		state.Shards = make([]shardChunk, 0)
+		err = cursor.All(ctx, &state.Shards)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read chunks",
+			"An unexpected error occurred when reading chunks. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	state.TotalChunks = 0
+	for _, shard := range state.Shards {
+		state.TotalChunks += shard.Chunks
+	}
+	state.Id = types.StringValue(namespace)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read chunk distribution of %s", namespace))
+}
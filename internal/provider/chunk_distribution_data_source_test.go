@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAccChunkDistributionDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSharded(t)
+
+			admin := testAccClient(t).Database("admin")
+			_ = admin.RunCommand(context.Background(), bson.D{{Key: "enableSharding", Value: "test_sharded"}}).Err()
+			err := admin.RunCommand(context.Background(), bson.D{
+				{Key: "shardCollection", Value: "test_sharded.chunks"},
+				{Key: "key", Value: bson.D{{Key: "_id", Value: "hashed"}}},
+			}).Err()
+			if err != nil {
+				t.Fatalf("Unable to shard collection: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_chunk_distribution" "test" {
+	database = "test_sharded"
+	collection = "chunks"
+}
+`,
+				Check: func(s *terraform.State) error {
+					attributes := s.RootModule().Resources["data.mongodb_chunk_distribution.test"].Primary.Attributes
+					shards, _ := strconv.Atoi(attributes["shards.#"])
+					sum := 0
+					for i := 0; i < shards; i++ {
+						chunks, _ := strconv.Atoi(attributes[fmt.Sprintf("shards.%d.chunks", i)])
+						sum += chunks
+					}
+					if total := attributes["total_chunks"]; total != strconv.Itoa(sum) || sum == 0 {
+						return fmt.Errorf("expected total_chunks %s to be the sum of shard chunks %d", total, sum)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
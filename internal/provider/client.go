@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"golang.org/x/sync/semaphore"
+)
+
+// mongodbClient is the client shared with resources and data sources by the provider.
+type mongodbClient struct {
+	*mongo.Client
+
+	// connectionString is the connection URI equivalent to the options the client was created with,
+	// credentials redacted.
+	connectionString string
+
+	// stableAPI is whether the client uses the stable API, which has no connection URI option.
+	stableAPI bool
+
+	// ddlWriteConcern is the write concern of the operations creating databases, collections and indexes,
+	// nil to use the client write concern.
+	ddlWriteConcern *writeconcern.WriteConcern
+
+	// readConcern is the read concern of the operations reading resources back, nil to use the client read concern.
+	readConcern *readconcern.ReadConcern
+
+	// readPreference is the read preference of the operations reading resources back, nil to use the primary.
+	// Writes, and the reads they depend on, always go to the primary.
+	readPreference *readpref.ReadPref
+
+	// defaultCollationLocale is the locale of the collation of collections created without collation.
+	defaultCollationLocale string
+
+	// maxTime is the time limit the server aborts the long running commands after, 0 for no limit.
+	maxTime time.Duration
+
+	// authDatabase is the provider auth_database, the default database of users and roles.
+	authDatabase string
+
+	// strictDatabase makes collections and indexes creation fail when their database does not exist.
+	strictDatabase bool
+
+	// operations limits the resource operations running at the same time, nil for no limit.
+	operations *semaphore.Weighted
+
+	// session is the explicit session all operations run in when a session tag or causal consistency is set.
+	// Sessions are not safe for concurrent use, so operations using it are serialized.
+	session      mongo.Session
+	sessionTag   string
+	sessionMutex sync.Mutex
+}
+
+var (
+	// openClients tracks the clients created by the provider so they are closed on shutdown.
+	openClients      []*mongodbClient
+	openClientsMutex sync.Mutex
+)
+
+// trackClient registers the client to be closed when the provider server stops.
+func trackClient(c *mongodbClient) {
+	openClientsMutex.Lock()
+	openClients = append(openClients, c)
+	openClientsMutex.Unlock()
+}
+
+// startSession starts the session all operations run in. When causalConsistency is set, reads in the session,
+// e.g. of data sources, observe the writes done before them in the session, e.g. by resources.
+// A non empty sessionTag is sent as the comment of a ping run in the session, so that the session id of all
+// operations can be related to the tag in the profiler.
+func (c *mongodbClient) startSession(ctx context.Context, sessionTag string, causalConsistency bool) error {
+	session, err := c.StartSession(options.Session().SetCausalConsistency(causalConsistency))
+	if err != nil {
+		return err
+	}
+
+	if sessionTag != "" {
+		err = c.Database("admin").RunCommand(mongo.NewSessionContext(ctx, session), bson.D{
+			{Key: "ping", Value: 1},
+			{Key: "comment", Value: sessionTag},
+		}).Err()
+		if err != nil {
+			session.EndSession(ctx)
+			return err
+		}
+	}
+
+	c.session = session
+	c.sessionTag = sessionTag
+
+	return nil
+}
+
+// withSession returns a context running operations in the provider session, if any.
+// The returned function must be called once the operation is done.
+func (c *mongodbClient) withSession(ctx context.Context) (context.Context, func()) {
+	if c.session == nil {
+		return ctx, func() {}
+	}
+
+	c.sessionMutex.Lock()
+	return mongo.NewSessionContext(ctx, c.session), c.sessionMutex.Unlock
+}
+
+// withOperation waits for the resources operations running at the same time to be under the limit, then returns
+// a context running the operation in the provider session, if any. It fails when the context is done first.
+func (c *mongodbClient) withOperation(ctx context.Context) (context.Context, func(), error) {
+	if c.operations == nil {
+		ctx, release := c.withSession(ctx)
+		return ctx, release, nil
+	}
+
+	// The slot is acquired before the session, so that an operation holding the session never waits for a slot.
+	if err := c.operations.Acquire(ctx, 1); err != nil {
+		return ctx, nil, err
+	}
+	ctx, release := c.withSession(ctx)
+	return ctx, func() {
+		release()
+		c.operations.Release(1)
+	}, nil
+}
+
+// ddlDatabase returns the database to run operations creating databases, collections and indexes with.
+func (c *mongodbClient) ddlDatabase(name string) *mongo.Database {
+	if c.ddlWriteConcern == nil {
+		return c.Database(name)
+	}
+	return c.Database(name, options.Database().SetWriteConcern(c.ddlWriteConcern))
+}
+
+// readDatabase returns the database to read resources back with.
+func (c *mongodbClient) readDatabase(name string) *mongo.Database {
+	opts := options.Database()
+	if c.readConcern != nil {
+		opts.SetReadConcern(c.readConcern)
+	}
+	if c.readPreference != nil {
+		opts.SetReadPreference(c.readPreference)
+	}
+	return c.Database(name, opts)
+}
+
+// userDatabase returns the database users and roles are defined in when not configured.
+func (c *mongodbClient) userDatabase() string {
+	if c.authDatabase != "" {
+		return c.authDatabase
+	}
+	return "admin"
+}
+
+// checkDatabase reports an error when strict database is enabled and the database does not exist.
+func (c *mongodbClient) checkDatabase(ctx context.Context, databaseName string, addError func(string, string)) {
+	if !c.strictDatabase {
+		return
+	}
+
+	names, err := c.ListDatabaseNames(ctx, bson.D{{Key: "name", Value: databaseName}})
+	if err != nil {
+		addError(
+			"Unable to list databases",
+			"An unexpected error occurred when listing databases. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	if len(names) == 0 {
+		addError(
+			"Database not found",
+			fmt.Sprintf("Database %s does not exist and strict_database is enabled. "+
+				"Create the database first, e.g. with a mongodb_database resource, or check the database name.", databaseName),
+		)
+	}
+}
+
+// close ends the provider session, if any, and disconnects the client. Disconnecting sends endSessions
+// to the server for the pooled sessions, and closes the connections.
+func (c *mongodbClient) close(ctx context.Context) error {
+	if c.session != nil {
+		c.session.EndSession(ctx)
+		c.session = nil
+	}
+	return c.Disconnect(ctx)
+}
+
+// CloseClients closes the clients created by the provider. It is called when the provider server stops.
+func CloseClients(ctx context.Context) {
+	openClientsMutex.Lock()
+	defer openClientsMutex.Unlock()
+
+	for _, c := range openClients {
+		_ = c.close(ctx)
+	}
+	openClients = nil
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &collectionCompactResource{}
+	_ resource.ResourceWithConfigure = &collectionCompactResource{}
+)
+
+// collectionCompactResource is the resource implementation.
+type collectionCompactResource struct {
+	client *mongodbClient
+}
+
+// collectionCompactResourceModel maps the resource schema data.
+type collectionCompactResourceModel struct {
+	Database   string       `tfsdk:"database"`
+	Collection string       `tfsdk:"collection"`
+	Trigger    *string      `tfsdk:"trigger"`
+	Force      *bool        `tfsdk:"force"`
+	BytesFreed types.Int64  `tfsdk:"bytes_freed"`
+	Id         types.String `tfsdk:"id"`
+}
+
+// NewCollectionCompactResource is a helper function to simplify the provider implementation.
+func NewCollectionCompactResource() resource.Resource {
+	return &collectionCompactResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *collectionCompactResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB collection compact resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	tflog.Info(ctx, "Configured MongoDB collection compact resource")
+}
+
+// Metadata returns the resource type name.
+func (r *collectionCompactResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_compact"
+}
+
+// Schema defines the schema for the resource.
+func (r *collectionCompactResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Run the compact command on a collection to reclaim storage. The command runs on creation and " +
+			"every time trigger changes, changing force alone does not run it. Changing database or collection " +
+			"replaces the resource, which compacts the new collection. Compact can be resource intensive and, depending on the server version, " +
+			"block operations on the database: schedule it in a maintenance window.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database of the collection to compact.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"collection": schema.StringAttribute{
+				Description: "Name of the collection to compact.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"trigger": schema.StringAttribute{
+				Description: "Arbitrary value, e.g. a timestamp, compact runs again when it changes.",
+				Optional:    true,
+			},
+			"force": schema.BoolAttribute{
+				Description: "Allow compact to run on the primary of a replica set. Applies from the next compact.",
+				Optional:    true,
+			},
+			"bytes_freed": schema.Int64Attribute{
+				Description: "Bytes freed by the last compact, when reported by the server.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Create runs compact and sets the initial Terraform state.
+func (r *collectionCompactResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan collectionCompactResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	r.compact(ctx, &plan, resp.Diagnostics.AddError)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the Terraform state as is, compact has nothing to read back.
+func (r *collectionCompactResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update runs compact again when trigger changes and sets the updated Terraform state on success.
+func (r *collectionCompactResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state collectionCompactResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	if reflect.DeepEqual(plan.Trigger, state.Trigger) {
+		// Only force changed, it is saved for the next compact.
+		plan.BytesFreed = state.BytesFreed
+		plan.Id = state.Id
+	} else {
+		r.compact(ctx, &plan, resp.Diagnostics.AddError)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the Terraform state, compact cannot be undone.
+func (r *collectionCompactResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *collectionCompactResource) compact(ctx context.Context, plan *collectionCompactResourceModel, addError func(string, string)) {
+	databaseName := plan.Database
+	collectionName := plan.Collection
+
+	tflog.Warn(ctx, fmt.Sprintf("Compacting collection %s.%s, this can impact the server performance", databaseName, collectionName))
+
+	command := bson.D{{Key: "compact", Value: collectionName}}
+	if plan.Force != nil {
+		command = append(command, bson.E{Key: "force", Value: *plan.Force})
+	}
+	if r.client.maxTime > 0 {
+		command = append(command, bson.E{Key: "maxTimeMS", Value: r.client.maxTime.Milliseconds()})
+	}
+
+	var result struct {
+		BytesFreed *int64 `bson:"bytesFreed"`
+	}
+	err := r.client.Database(databaseName).RunCommand(ctx, command).Decode(&result)
+	if isMaxTimeExpired(err) {
+		addError(
+			"Compact exceeded max_time_ms",
+			fmt.Sprintf("The server aborted the compaction of collection %s.%s after the provider max_time_ms of %d ms. "+
+				"Increase max_time_ms, or compact the collection when the server is less loaded.",
+				databaseName, collectionName, r.client.maxTime.Milliseconds()),
+		)
+		return
+	}
+	if err != nil {
+		addError(
+			"Unable to compact collection",
+			"An unexpected error occurred when compacting collection. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.BytesFreed = types.Int64PointerValue(result.BytesFreed)
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
+
+	tflog.Debug(ctx, fmt.Sprintf("Compacted collection %s.%s", databaseName, collectionName))
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccCollectionCompactResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "compacted" {
+	database = "test_db"
+	name = "test_compacted"
+}
+
+resource "mongodb_collection_compact" "test" {
+	database = mongodb_collection.compacted.database
+	collection = mongodb_collection.compacted.name
+	trigger = "1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection_compact.test", "id", "test_db.test_compacted"),
+				),
+			},
+			// Changing the trigger runs compact again
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "compacted" {
+	database = "test_db"
+	name = "test_compacted"
+}
+
+resource "mongodb_collection_compact" "test" {
+	database = mongodb_collection.compacted.database
+	collection = mongodb_collection.compacted.name
+	trigger = "2"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection_compact.test", "trigger", "2"),
+				),
+			},
+			// Changing force alone updates the resource in place without running compact
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "compacted" {
+	database = "test_db"
+	name = "test_compacted"
+}
+
+resource "mongodb_collection_compact" "test" {
+	database = mongodb_collection.compacted.database
+	collection = mongodb_collection.compacted.name
+	trigger = "2"
+	force = true
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection_compact.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection_compact.test", "force", "true"),
+				),
+			},
+		},
+	})
+}
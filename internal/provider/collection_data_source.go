@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &collectionDataSource{}
+	_ datasource.DataSourceWithConfigure = &collectionDataSource{}
+)
+
+// collectionDataSource is the data source implementation.
+type collectionDataSource struct {
+	client *mongodbClient
+}
+
+// collectionDataSourceModel maps the data source schema data.
+type collectionDataSourceModel struct {
+	Database     string       `tfsdk:"database"`
+	Name         string       `tfsdk:"name"`
+	Type         string       `tfsdk:"type"`
+	Capped       bool         `tfsdk:"capped"`
+	SizeInBytes  types.Int64  `tfsdk:"size_in_bytes"`
+	MaxDocuments types.Int64  `tfsdk:"max_documents"`
+	Validator    types.String `tfsdk:"validator"`
+	Indexes      []string     `tfsdk:"indexes"`
+	Id           types.String `tfsdk:"id"`
+}
+
+// NewCollectionDataSource is a helper function to simplify the provider implementation.
+func NewCollectionDataSource() datasource.DataSource {
+	return &collectionDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *collectionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB collection data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB collection data source")
+}
+
+// Metadata returns the data source type name.
+func (d *collectionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+// Schema defines the schema for the data source.
+func (d *collectionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read an existing collection, e.g. one not managed by Terraform, with its options and indexes. " +
+			"Fails when the collection does not exist.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database of the collection.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the collection.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the collection, one of collection, timeseries or view.",
+				Computed:    true,
+			},
+			"capped": schema.BoolAttribute{
+				Description: "Whether the collection is capped.",
+				Computed:    true,
+			},
+			"size_in_bytes": schema.Int64Attribute{
+				Description: "Maximum size of the capped collection, null when not capped.",
+				Computed:    true,
+			},
+			"max_documents": schema.Int64Attribute{
+				Description: "Maximum number of documents of the capped collection, null when not capped or not limited.",
+				Computed:    true,
+			},
+			"validator": schema.StringAttribute{
+				Description: "Validator of the collection documents, in canonical Extended JSON. Null when the collection has no validator.",
+				Computed:    true,
+			},
+			"indexes": schema.ListAttribute{
+				Description: "Names of the indexes of the collection, sorted. Empty for views.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *collectionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state collectionDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database
+	collectionName := state.Name
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading collection %s.%s", databaseName, collectionName))
+
+	db := d.client.readDatabase(databaseName)
+	foundOptions, err := readCollectionOptions(ctx, db, collectionName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list collections",
+			"An unexpected error occurred when listing collections. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	if foundOptions == nil {
+		resp.Diagnostics.AddError(
+			"Collection not found",
+			fmt.Sprintf("Collection %s.%s does not exist", databaseName, collectionName),
+		)
+		return
+	}
+
+	err = state.setOptions(foundOptions)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse collection validator",
+			"An unexpected error occurred when parsing the collection validator. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	// Views have no indexes, listing them fails.
+	state.Indexes = []string{}
+	if state.Type != "view" {
+		specifications, err := db.Collection(collectionName).Indexes().ListSpecifications(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to list indexes",
+				"An unexpected error occurred when listing indexes. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+		for _, specification := range specifications {
+			state.Indexes = append(state.Indexes, specification.Name)
+		}
+		slices.Sort(state.Indexes)
+	}
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read collection %s.%s", databaseName, collectionName))
+}
+
+// setOptions sets the attributes read from the collection options.
+func (m *collectionDataSourceModel) setOptions(options *collectionOptions) error {
+	m.Type = options.Type
+	m.Capped = options.Capped
+	size, maxDocuments := options.toCappedLimits(nil)
+	m.SizeInBytes = types.Int64PointerValue(size)
+	m.MaxDocuments = types.Int64PointerValue(maxDocuments)
+
+	found, err := options.toValidation(nil)
+	if err != nil {
+		return err
+	}
+	m.Validator = types.StringNull()
+	if found != nil {
+		m.Validator = types.StringValue(found.Validator)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCollectionDataSourceModelSetOptions(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{
+		{Key: "capped", Value: true},
+		{Key: "size", Value: int64(4096)},
+		{Key: "validator", Value: bson.D{{Key: "name", Value: bson.D{{Key: "$exists", Value: true}}}}},
+	})
+	var opts collectionOptions
+	if err := bson.Unmarshal(raw, &opts); err != nil {
+		t.Fatalf("Unable to parse options: %v", err)
+	}
+	opts.Type = "collection"
+
+	var model collectionDataSourceModel
+	if err := model.setOptions(&opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if model.Type != "collection" || !model.Capped || model.SizeInBytes.ValueInt64() != 4096 || !model.MaxDocuments.IsNull() ||
+		model.Validator.ValueString() != `{"name":{"$exists":true}}` {
+		t.Errorf("Unexpected attributes %+v", model)
+	}
+
+	model = collectionDataSourceModel{}
+	if err := model.setOptions(&collectionOptions{Type: "collection"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if model.Capped || !model.SizeInBytes.IsNull() || !model.MaxDocuments.IsNull() || !model.Validator.IsNull() {
+		t.Errorf("Expected no options, got %+v", model)
+	}
+}
+
+func TestAccCollectionDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_collection_data_source"
+	name = "capped"
+	capped = true
+	size_in_bytes = 4096
+	validation = {
+		validator = jsonencode({ name = { "$exists" = true } })
+	}
+}
+
+resource "mongodb_index" "test" {
+	database = mongodb_collection.test.database
+	collection = mongodb_collection.test.name
+	name = "name"
+	keys = [{ field = "name", type = "asc" }]
+}
+
+data "mongodb_collection" "test" {
+	database = mongodb_index.test.database
+	name = mongodb_index.test.collection
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_collection.test", "type", "collection"),
+					resource.TestCheckResourceAttr("data.mongodb_collection.test", "capped", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_collection.test", "size_in_bytes", "4096"),
+					resource.TestCheckNoResourceAttr("data.mongodb_collection.test", "max_documents"),
+					resource.TestCheckResourceAttr("data.mongodb_collection.test", "validator", `{"name":{"$exists":true}}`),
+					resource.TestCheckResourceAttr("data.mongodb_collection.test", "indexes.#", "2"),
+					resource.TestCheckResourceAttr("data.mongodb_collection.test", "indexes.0", "_id_"),
+					resource.TestCheckResourceAttr("data.mongodb_collection.test", "indexes.1", "name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCollectionDataSourceNotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_collection" "test" {
+	database = "test_collection_data_source"
+	name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile("Collection not found"),
+			},
+		},
+	})
+}
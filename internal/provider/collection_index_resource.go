@@ -0,0 +1,657 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &collectionIndexResource{}
+	_ resource.ResourceWithConfigure      = &collectionIndexResource{}
+	_ resource.ResourceWithImportState    = &collectionIndexResource{}
+	_ resource.ResourceWithValidateConfig = &collectionIndexResource{}
+)
+
+// collectionIndexResource is the resource implementation.
+type collectionIndexResource struct {
+	client *mongodbClient
+}
+
+// collectionIndexResourceModel maps the resource schema data.
+type collectionIndexResourceModel struct {
+	Database   string         `tfsdk:"database"`
+	Collection string         `tfsdk:"collection"`
+	Indexes    []managedIndex `tfsdk:"indexes"`
+	Id         types.String   `tfsdk:"id"`
+}
+
+// managedIndex maps an index of the indexes attribute.
+type managedIndex struct {
+	Name                    string     `tfsdk:"name"`
+	Keys                    []indexKey `tfsdk:"keys"`
+	Unique                  *bool      `tfsdk:"unique"`
+	Sparse                  *bool      `tfsdk:"sparse"`
+	ExpireAfterSeconds      *int32     `tfsdk:"expire_after_seconds"`
+	PartialFilterExpression *string    `tfsdk:"partial_filter_expression"`
+}
+
+// NewCollectionIndexResource is a helper function to simplify the provider implementation.
+func NewCollectionIndexResource() resource.Resource {
+	return &collectionIndexResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *collectionIndexResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB collection index resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	tflog.Info(ctx, "Configured MongoDB collection index resource")
+}
+
+// Metadata returns the resource type name.
+func (r *collectionIndexResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_index"
+}
+
+// Schema defines the schema for the resource.
+func (r *collectionIndexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manage all the indexes of a collection at once. Indexes which are not declared, e.g. created outside " +
+			"Terraform, are dropped on apply, the _id index aside. An index whose keys or options change is dropped then " +
+			"created again. Do not combine with mongodb_index resources on the same collection.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database of the collection.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"collection": schema.StringAttribute{
+				Description: "Name of the collection, created along with the first index when it does not exist.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"indexes": schema.SetNestedAttribute{
+				Description: "Indexes of the collection, the _id index aside.",
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the index.",
+							Required:    true,
+						},
+						"keys": schema.ListNestedAttribute{
+							Description: "The list of fields composing the index.",
+							Required:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"field": schema.StringAttribute{
+										Description: "The name of the field to index.",
+										Required:    true,
+									},
+									"type": schema.StringAttribute{
+										Description: "The type of index for this field, one of asc, desc, 2d, 2dsphere, hashed or text.",
+										Required:    true,
+									},
+								},
+							},
+						},
+						"unique": schema.BoolAttribute{
+							Description: "Is it a unique index.",
+							Optional:    true,
+						},
+						"sparse": schema.BoolAttribute{
+							Description: "Is it a sparse index, which skips the documents missing the indexed fields.",
+							Optional:    true,
+						},
+						"expire_after_seconds": schema.Int64Attribute{
+							Description: "Documents ttl in seconds for ttl indexes.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(0, math.MaxInt32),
+							},
+						},
+						"partial_filter_expression": schema.StringAttribute{
+							Description: "Filter of the documents to index, as a document in MongoDB Extended JSON.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the index names are unique, the set only telling indexes apart by all their attributes,
+// and each index specification as the index resource does.
+func (r *collectionIndexResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var indexesValue types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("indexes"), &indexesValue)...)
+	if resp.Diagnostics.HasError() || indexesValue.IsNull() || indexesValue.IsUnknown() {
+		return
+	}
+
+	// Indexes with unknown values are only validated on apply.
+	var indexes []managedIndex
+	if diags := indexesValue.ElementsAs(ctx, &indexes, false); diags.HasError() {
+		return
+	}
+
+	names := make(map[string]bool)
+	for _, index := range indexes {
+		switch {
+		case index.Name == "_id_":
+			resp.Diagnostics.AddAttributeError(
+				path.Root("indexes"),
+				"Reserved index name",
+				"The _id_ index is created with the collection and cannot be managed.",
+			)
+		case names[index.Name]:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("indexes"),
+				"Duplicate index name",
+				fmt.Sprintf("Index %s is declared more than once.", index.Name),
+			)
+		}
+		names[index.Name] = true
+
+		spec := indexSpec{
+			keys:    index.Keys,
+			ttl:     index.ExpireAfterSeconds != nil,
+			unique:  index.Unique != nil && *index.Unique,
+			sparse:  index.Sparse != nil && *index.Sparse,
+			partial: index.PartialFilterExpression != nil,
+		}
+		resp.Diagnostics.Append(indexDiagnostics(index.Name, spec.validate())...)
+	}
+}
+
+// indexDiagnostics reports the diagnostics of the specification of an index on the indexes attribute, the paths of
+// the index resource attributes they are attached to not existing in this resource.
+func indexDiagnostics(name string, specDiags diag.Diagnostics) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, d := range specDiags {
+		detail := fmt.Sprintf("Index %s: %s", name, d.Detail())
+		if d.Severity() == diag.SeverityError {
+			diags.AddAttributeError(path.Root("indexes"), d.Summary(), detail)
+		} else {
+			diags.AddAttributeWarning(path.Root("indexes"), d.Summary(), detail)
+		}
+	}
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *collectionIndexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan collectionIndexResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating indexes of %s.%s", plan.Database, plan.Collection))
+
+	r.client.checkDatabase(ctx, plan.Database, resp.Diagnostics.AddError)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", plan.Database, plan.Collection))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Indexes of %s.%s created", plan.Database, plan.Collection))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *collectionIndexResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state collectionIndexResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := r.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database
+	collectionName := state.Collection
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading indexes of %s.%s", databaseName, collectionName))
+
+	db := r.client.readDatabase(databaseName)
+	exists, err := collectionExists(ctx, db, collectionName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list collections",
+			"An unexpected error occurred when listing collections. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, fmt.Sprintf("Collection %s.%s not found, removing its indexes from the state", databaseName, collectionName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	documents, err := listIndexes(ctx, db.Collection(collectionName))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list indexes",
+			"An unexpected error occurred when listing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Indexes, err = readManagedIndexes(state.Indexes, documents)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse indexes",
+			"An unexpected error occurred when parsing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read %d indexes of %s.%s", len(state.Indexes), databaseName, collectionName))
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *collectionIndexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan collectionIndexResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating indexes of %s.%s", plan.Database, plan.Collection))
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", plan.Database, plan.Collection))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Indexes of %s.%s updated", plan.Database, plan.Collection))
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *collectionIndexResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state collectionIndexResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := state.Database
+	collectionName := state.Collection
+	collection := r.client.Database(databaseName).Collection(collectionName)
+
+	for _, index := range state.Indexes {
+		tflog.Debug(ctx, fmt.Sprintf("Dropping index %s.%s.%s", databaseName, collectionName, index.Name))
+
+		_, err = collection.Indexes().DropOne(ctx, index.Name)
+		if isNamespaceNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Collection %s.%s already dropped", databaseName, collectionName))
+			return
+		}
+		if isIndexNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Index %s.%s.%s already dropped", databaseName, collectionName, index.Name))
+			err = nil
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to drop index",
+				"An unexpected error occurred when dropping index "+index.Name+". "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Dropped indexes of %s.%s", databaseName, collectionName))
+}
+
+// ImportState imports the indexes of an existing collection into Terraform state.
+func (r *collectionIndexResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseCollectionId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid id format. Should be <database>.<collection>.",
+			"An unexpected error occurred when importing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), id.database)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection"), id.collection)...)
+}
+
+// reconcile drops the indexes of the collection which are not planned or differ from the plan, then creates the
+// planned indexes which are missing. Matching indexes are left untouched. The planned indexes are all converted
+// before the first drop, so that an invalid index does not leave the collection with indexes dropped.
+func (r *collectionIndexResource) reconcile(ctx context.Context, plan *collectionIndexResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	databaseName := plan.Database
+	collectionName := plan.Collection
+	db := r.client.ddlDatabase(databaseName)
+	collection := db.Collection(collectionName)
+
+	exists, err := collectionExists(ctx, db, collectionName)
+	if err != nil {
+		diags.AddError(
+			"Unable to list collections",
+			"An unexpected error occurred when listing collections. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return diags
+	}
+
+	// The collection is created along with the indexes.
+	var existing []indexDocument
+	if exists {
+		existing, err = listIndexes(ctx, collection)
+	}
+	if err != nil {
+		diags.AddError(
+			"Unable to list indexes",
+			"An unexpected error occurred when listing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return diags
+	}
+
+	drop, create := planIndexChanges(plan.Indexes, existing)
+
+	models := make([]mongo.IndexModel, 0, len(create))
+	for _, index := range create {
+		model, err := index.toIndexModel()
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("indexes"),
+				"Invalid partial filter expression",
+				fmt.Sprintf("The partial filter expression of index %s must be a document in MongoDB Extended JSON.\n\nError: %s", index.Name, err.Error()),
+			)
+			return diags
+		}
+		models = append(models, model)
+	}
+
+	for _, name := range drop {
+		tflog.Debug(ctx, fmt.Sprintf("Dropping index %s.%s.%s", databaseName, collectionName, name))
+		_, err = collection.Indexes().DropOne(ctx, name)
+		if err != nil && !isIndexNotFound(err) {
+			diags.AddError(
+				"Unable to drop index",
+				"An unexpected error occurred when dropping index "+name+". "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return diags
+		}
+	}
+
+	if len(models) == 0 {
+		return diags
+	}
+
+	createOptions := options.CreateIndexes()
+	if r.client.maxTime > 0 {
+		//nolint:staticcheck // maxTimeMS bounds the build server side, whatever the client context
+		createOptions.SetMaxTime(r.client.maxTime)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating %d indexes on %s.%s", len(models), databaseName, collectionName))
+	_, err = collection.Indexes().CreateMany(ctx, models, createOptions)
+	if err != nil {
+		diags.AddError(
+			"Unable to create indexes",
+			"An unexpected error occurred when creating indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+	}
+	return diags
+}
+
+// collectionExists checks whether the collection exists in the database, listing the collections of exactly its name.
+func collectionExists(ctx context.Context, db *mongo.Database, collectionName string) (bool, error) {
+	names, err := db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: collectionName}})
+	if err != nil {
+		return false, err
+	}
+	return len(names) > 0, nil
+}
+
+// listIndexes lists the indexes of the collection.
+func listIndexes(ctx context.Context, collection *mongo.Collection) ([]indexDocument, error) {
+	var documents []indexDocument
+	cursor, err := collection.Indexes().List(ctx)
+	if err == nil {
+		err = cursor.All(ctx, &documents)
+	}
+	return documents, err
+}
+
+// planIndexChanges returns the names of the existing indexes to drop, not planned or differing from the plan, and
+// the planned indexes to create, missing or dropped. The _id_ index is never dropped.
+func planIndexChanges(planned []managedIndex, existing []indexDocument) ([]string, []managedIndex) {
+	plannedByName := make(map[string]*managedIndex)
+	for i := range planned {
+		plannedByName[planned[i].Name] = &planned[i]
+	}
+
+	kept := make(map[string]bool)
+	var drop []string
+	for i := range existing {
+		document := &existing[i]
+		if document.Name == "_id_" {
+			continue
+		}
+		if index, ok := plannedByName[document.Name]; ok && index.matches(document) {
+			kept[document.Name] = true
+			continue
+		}
+		drop = append(drop, document.Name)
+	}
+
+	var create []managedIndex
+	for _, index := range planned {
+		if !kept[index.Name] {
+			create = append(create, index)
+		}
+	}
+	return drop, create
+}
+
+// matches checks whether an index listed by the server has the keys and options of the index, its name aside.
+func (i *managedIndex) matches(document *indexDocument) bool {
+	found, err := fromMongoCollation(document.Collation)
+	if err != nil {
+		return false
+	}
+	// The indexes inherit the default collation of the collection.
+	model := indexResourceModel{
+		Keys:                    i.Keys,
+		Unique:                  i.Unique,
+		Sparse:                  i.Sparse,
+		ExpireAfterSeconds:      i.ExpireAfterSeconds,
+		PartialFilterExpression: i.PartialFilterExpression,
+		Collation:               found,
+	}
+	return model.matches(document)
+}
+
+// toIndexModel converts the index into the model to create it with.
+func (i *managedIndex) toIndexModel() (mongo.IndexModel, error) {
+	indexOptions := options.Index().SetName(i.Name)
+	indexOptions.Unique = i.Unique
+	indexOptions.Sparse = i.Sparse
+	indexOptions.ExpireAfterSeconds = i.ExpireAfterSeconds
+	if i.PartialFilterExpression != nil {
+		partialFilter, err := parseValidator(*i.PartialFilterExpression)
+		if err != nil {
+			return mongo.IndexModel{}, err
+		}
+		indexOptions.SetPartialFilterExpression(partialFilter)
+	}
+	return mongo.IndexModel{Keys: toMongoIndexKeys(i.Keys), Options: indexOptions}, nil
+}
+
+// readManagedIndexes converts the indexes listed by the server, the _id_ index aside, keeping the configured
+// values of the current indexes of the same name while they are equivalent.
+func readManagedIndexes(current []managedIndex, documents []indexDocument) ([]managedIndex, error) {
+	currentByName := make(map[string]*managedIndex)
+	for i := range current {
+		currentByName[current[i].Name] = &current[i]
+	}
+
+	indexes := []managedIndex{}
+	for i := range documents {
+		document := &documents[i]
+		if document.Name == "_id_" {
+			continue
+		}
+		previous, ok := currentByName[document.Name]
+		if !ok {
+			previous = &managedIndex{}
+		}
+
+		keys, err := document.keys()
+		if err != nil {
+			return nil, err
+		}
+		// The server keeps the text fields of text indexes in its own order, keep the configured one.
+		if sameIndexKeys(previous.Keys, keys) {
+			keys = previous.Keys
+		}
+		partialFilter, err := readPartialFilter(previous.PartialFilterExpression, document.PartialFilterExpression)
+		if err != nil {
+			return nil, err
+		}
+
+		indexes = append(indexes, managedIndex{
+			Name:                    document.Name,
+			Keys:                    keys,
+			Unique:                  readBoolOption(previous.Unique, document.Unique),
+			Sparse:                  readBoolOption(previous.Sparse, document.Sparse),
+			ExpireAfterSeconds:      document.ExpireAfterSeconds,
+			PartialFilterExpression: partialFilter,
+		})
+	}
+	return indexes, nil
+}
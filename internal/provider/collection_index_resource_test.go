@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestPlanIndexChanges(t *testing.T) {
+	idKey, _ := bson.Marshal(bson.D{{Key: "_id", Value: 1}})
+	emailKey, _ := bson.Marshal(bson.D{{Key: "email", Value: 1}})
+	kindKey, _ := bson.Marshal(bson.D{{Key: "kind", Value: 1}})
+	createdKey, _ := bson.Marshal(bson.D{{Key: "created_at", Value: -1}})
+	collationDocument, _ := bson.Marshal(bson.D{{Key: "locale", Value: "fr"}, {Key: "strength", Value: 3}})
+	unique := true
+
+	existing := []indexDocument{
+		{Name: "_id_", Key: idKey},
+		// Matching, the collection default collation aside.
+		{Name: "email", Key: emailKey, Unique: true, Collation: collationDocument},
+		// Planned with other options.
+		{Name: "kind", Key: kindKey},
+		// Not planned, e.g. created outside Terraform.
+		{Name: "created_at", Key: createdKey},
+	}
+	planned := []managedIndex{
+		{Name: "email", Keys: []indexKey{{Field: "email", Type: "asc"}}, Unique: &unique},
+		{Name: "kind", Keys: []indexKey{{Field: "kind", Type: "desc"}}},
+		{Name: "status", Keys: []indexKey{{Field: "status", Type: "asc"}}},
+	}
+
+	drop, create := planIndexChanges(planned, existing)
+	if !slices.Equal(drop, []string{"kind", "created_at"}) {
+		t.Errorf("Expected kind and created_at to be dropped, got %v", drop)
+	}
+	var created []string
+	for _, index := range create {
+		created = append(created, index.Name)
+	}
+	if !slices.Equal(created, []string{"kind", "status"}) {
+		t.Errorf("Expected kind and status to be created, got %v", created)
+	}
+}
+
+func TestReadManagedIndexes(t *testing.T) {
+	idKey, _ := bson.Marshal(bson.D{{Key: "_id", Value: 1}})
+	textKey, _ := bson.Marshal(bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: 1}})
+	weights, _ := bson.Marshal(bson.D{{Key: "body", Value: 1}, {Key: "title", Value: 1}})
+	kindKey, _ := bson.Marshal(bson.D{{Key: "kind", Value: 1}})
+	filter, _ := bson.Marshal(bson.D{{Key: "kind", Value: "event"}})
+	configuredFilter := `{ "kind": "event" }`
+	notUnique := false
+	ttl := int32(3600)
+
+	current := []managedIndex{
+		{Name: "text", Keys: []indexKey{{Field: "title", Type: "text"}, {Field: "body", Type: "text"}}},
+		{Name: "kind", Keys: []indexKey{{Field: "kind", Type: "asc"}}, Unique: &notUnique, PartialFilterExpression: &configuredFilter},
+	}
+	documents := []indexDocument{
+		{Name: "_id_", Key: idKey},
+		{Name: "text", Key: textKey, Weights: weights},
+		{Name: "kind", Key: kindKey, PartialFilterExpression: filter},
+		{Name: "expiry", Key: kindKey, ExpireAfterSeconds: &ttl, Sparse: true},
+	}
+
+	indexes, err := readManagedIndexes(current, documents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(indexes) != 3 {
+		t.Fatalf("Expected the indexes but _id_, got %+v", indexes)
+	}
+	if indexes[0].Keys[0].Field != "title" {
+		t.Errorf("Expected the configured order of the text fields, got %v", indexes[0].Keys)
+	}
+	if indexes[1].Unique == nil || *indexes[1].Unique || indexes[1].PartialFilterExpression != &configuredFilter {
+		t.Errorf("Expected the configured options to be kept, got %+v", indexes[1])
+	}
+	if indexes[2].Unique != nil || indexes[2].Sparse == nil || !*indexes[2].Sparse || indexes[2].ExpireAfterSeconds == nil || *indexes[2].ExpireAfterSeconds != ttl {
+		t.Errorf("Expected the options of the index created outside Terraform, got %+v", indexes[2])
+	}
+}
+
+func TestAccCollectionIndexResource(t *testing.T) {
+	ctx := context.Background()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection_index" "test" {
+	database = "test_collection_index"
+	collection = "events"
+	indexes = [
+		{ name = "kind", keys = [{ field = "kind", type = "asc" }, { field = "created_at", type = "desc" }] },
+		{ name = "email", keys = [{ field = "email", type = "asc" }], unique = true },
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection_index.test", "indexes.#", "2"),
+					resource.TestCheckResourceAttr("mongodb_collection_index.test", "id", "test_collection_index.events"),
+				),
+			},
+			// An index created outside Terraform is dropped, and a changed index is created again.
+			// The partial filter is in canonical Extended JSON, as imported.
+			{
+				PreConfig: func() {
+					_, err := testAccClient(t).Database("test_collection_index").Collection("events").Indexes().CreateOne(ctx, mongo.IndexModel{
+						Keys:    bson.D{{Key: "manual", Value: 1}},
+						Options: options.Index().SetName("manual"),
+					})
+					if err != nil {
+						t.Fatalf("Unable to create index: %v", err)
+					}
+				},
+				Config: providerConfig + `
+resource "mongodb_collection_index" "test" {
+	database = "test_collection_index"
+	collection = "events"
+	indexes = [
+		{ name = "kind", keys = [{ field = "kind", type = "asc" }, { field = "created_at", type = "desc" }] },
+		{ name = "email", keys = [{ field = "email", type = "asc" }], unique = true, partial_filter_expression = "{\"email\":{\"$exists\":true}}" },
+		{ name = "expiry", keys = [{ field = "expires_at", type = "asc" }], expire_after_seconds = 0 },
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection_index.test", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					specifications, err := testAccClient(t).Database("test_collection_index").Collection("events").Indexes().ListSpecifications(ctx)
+					if err != nil {
+						return err
+					}
+					var names []string
+					for _, specification := range specifications {
+						names = append(names, specification.Name)
+					}
+					slices.Sort(names)
+					if !slices.Equal(names, []string{"_id_", "email", "expiry", "kind"}) {
+						return fmt.Errorf("expected the declared indexes only, got %v", names)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:                         "mongodb_collection_index.test",
+				ImportState:                          true,
+				ImportStateId:                        "test_collection_index.events",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "id",
+			},
+		},
+	})
+}
+
+func TestAccCollectionIndexResourceDropped(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_collection_index" "dropped" {
+	database = "test_collection_index"
+	collection = "dropped"
+	indexes = [
+		{ name = "kind", keys = [{ field = "kind", type = "asc" }] },
+	]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// The indexes of a collection dropped outside Terraform are created again along with the collection.
+			{
+				PreConfig: func() {
+					err := testAccClient(t).Database("test_collection_index").Collection("dropped").Drop(context.Background())
+					if err != nil {
+						t.Fatalf("Unable to drop collection: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection_index.dropped", plancheck.ResourceActionCreate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCollectionIndexResourceInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection_index" "invalid" {
+	database = "test_collection_index"
+	collection = "invalid"
+	indexes = [
+		{ name = "kind", keys = [{ field = "kind", type = "hashed" }], unique = true },
+	]
+}
+`,
+				ExpectError: regexp.MustCompile("Unique hashed index"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection_index" "invalid" {
+	database = "test_collection_index"
+	collection = "invalid"
+	indexes = [
+		{ name = "kind", keys = [{ field = "kind", type = "asc" }] },
+	]
+}
+`,
+			},
+			// The invalid partial filter is reported before the changed index is dropped.
+			{
+				Config: providerConfig + `
+resource "mongodb_collection_index" "invalid" {
+	database = "test_collection_index"
+	collection = "invalid"
+	indexes = [
+		{ name = "kind", keys = [{ field = "kind", type = "desc" }] },
+		{ name = "email", keys = [{ field = "email", type = "asc" }], partial_filter_expression = "{\"email\":" },
+	]
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid partial filter expression"),
+			},
+			{
+				PreConfig: func() {
+					specifications, err := testAccClient(t).Database("test_collection_index").Collection("invalid").Indexes().ListSpecifications(ctx)
+					if err != nil {
+						t.Fatalf("Unable to list indexes: %v", err)
+					}
+					if !slices.ContainsFunc(specifications, func(specification *mongo.IndexSpecification) bool { return specification.Name == "kind" }) {
+						t.Fatalf("Expected index kind to be kept, got %v", specifications)
+					}
+				},
+				Config: providerConfig + `
+resource "mongodb_collection_index" "invalid" {
+	database = "test_collection_index"
+	collection = "invalid"
+	indexes = [
+		{ name = "kind", keys = [{ field = "kind", type = "asc" }] },
+	]
+}
+`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -1,18 +1,31 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -22,25 +35,85 @@ var (
 	_ resource.Resource                = &collectionResource{}
 	_ resource.ResourceWithConfigure   = &collectionResource{}
 	_ resource.ResourceWithImportState = &collectionResource{}
+	_ resource.ResourceWithModifyPlan  = &collectionResource{}
 )
 
 // collectionResource is the resource implementation.
 type collectionResource struct {
-	client *mongo.Client
+	client *mongodbClient
 }
 
 // collectionResourceModel maps the resource schema data.
 type collectionResourceModel struct {
-	Database   string       `tfsdk:"database"`
-	Name       string       `tfsdk:"name"`
-	Validation *validation  `tfsdk:"validation"`
-	Id         types.String `tfsdk:"id"`
+	Namespace                    types.String    `tfsdk:"namespace"`
+	Database                     string          `tfsdk:"database"`
+	Name                         string          `tfsdk:"name"`
+	Validation                   *validation     `tfsdk:"validation"`
+	TimeSeries                   *timeSeries     `tfsdk:"timeseries"`
+	ClusteredIndex               *clusteredIndex `tfsdk:"clustered_index"`
+	Collation                    *collation      `tfsdk:"collation"`
+	Capped                       *bool           `tfsdk:"capped"`
+	SizeInBytes                  *int64          `tfsdk:"size_in_bytes"`
+	MaxDocuments                 *int64          `tfsdk:"max_documents"`
+	ChangeStreamPreAndPostImages *bool           `tfsdk:"change_stream_pre_and_post_images"`
+	Description                  *string         `tfsdk:"description"`
+	IndexCount                   types.Int64     `tfsdk:"index_count"`
+	Timeouts                     timeouts.Value  `tfsdk:"timeouts"`
+	Id                           types.String    `tfsdk:"id"`
 }
 
 type validation struct {
-	Validator string `tfsdk:"validator"`
+	Validator string       `tfsdk:"validator"`
+	Level     types.String `tfsdk:"level"`
+	Action    types.String `tfsdk:"action"`
 }
 
+type timeSeries struct {
+	TimeField          string       `tfsdk:"time_field"`
+	MetaField          *string      `tfsdk:"meta_field"`
+	Granularity        types.String `tfsdk:"granularity"`
+	ExpireAfterSeconds *int64       `tfsdk:"expire_after_seconds"`
+}
+
+type clusteredIndex struct {
+	Name               types.String `tfsdk:"name"`
+	ExpireAfterSeconds *int64       `tfsdk:"expire_after_seconds"`
+}
+
+// collectionOptions maps the options returned by listCollections that the resource reads back.
+type collectionOptions struct {
+	// Type is the type of the collection, e.g. collection or timeseries, returned aside the options.
+	Type string `bson:"-"`
+
+	ExpireAfterSeconds *int64   `bson:"expireAfterSeconds"`
+	Validator          bson.Raw `bson:"validator"`
+	ValidationLevel    string   `bson:"validationLevel"`
+	ValidationAction   string   `bson:"validationAction"`
+	Capped             bool     `bson:"capped"`
+	Size               *int64   `bson:"size"`
+	Max                *int64   `bson:"max"`
+	TimeSeries         *struct {
+		TimeField   string  `bson:"timeField"`
+		MetaField   *string `bson:"metaField"`
+		Granularity *string `bson:"granularity"`
+	} `bson:"timeseries"`
+	// clusteredIndex is a document for clustered collections, but only true for time-series collections.
+	ClusteredIndex bson.RawValue `bson:"clusteredIndex"`
+	Collation      bson.Raw      `bson:"collation"`
+
+	ChangeStreamPreAndPostImages struct {
+		Enabled bool `bson:"enabled"`
+	} `bson:"changeStreamPreAndPostImages"`
+
+	// ViewOn and Pipeline are the source and the aggregation pipeline of views.
+	ViewOn   string        `bson:"viewOn"`
+	Pipeline bson.RawValue `bson:"pipeline"`
+}
+
+// metadataCollection is the collection storing the descriptions of the collections of its database, which
+// MongoDB has no option for. Its documents have the collection name as _id and a description field.
+const metadataCollection = "_terraform_metadata"
+
 // NewCollectionResource is a helper function to simplify the provider implementation.
 func NewCollectionResource() resource.Resource {
 	return &collectionResource{}
@@ -53,11 +126,11 @@ func (r *collectionResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*mongo.Client)
+	client, ok := req.ProviderData.(*mongodbClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -72,21 +145,37 @@ func (r *collectionResource) Metadata(_ context.Context, req resource.MetadataRe
 }
 
 // Schema defines the schema for the resource.
-func (r *collectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *collectionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Create collections in MongoDB.",
 		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Description: "Namespace of the collection to create, as <database>.<collection>. Alternative to database and name.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("database")),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
 			"database": schema.StringAttribute{
 				Description: "Name of the database where to create the collection.",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "Name of the collection to create.",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -95,19 +184,169 @@ func (r *collectionResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 				Attributes: map[string]schema.Attribute{
 					"validator": schema.StringAttribute{
-						Description: "JSON schema validation rules for the collection.",
+						Description: "JSON schema validation rules for the collection, in MongoDB Extended JSON, e.g. `{\"$numberLong\": \"1\"}` for a long.",
+						Required:    true,
+					},
+					"level": schema.StringAttribute{
+						Description: "Which documents the validator applies to: strict for all inserts and updates, moderate for inserts " +
+							"and updates of valid documents only, or off. Defaults to strict.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("off", "strict", "moderate"),
+						},
+					},
+					"action": schema.StringAttribute{
+						Description: "Whether invalid documents are rejected, error, or only logged, warn. Defaults to error.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("error", "warn"),
+						},
+					},
+				},
+			},
+			"timeseries": schema.SingleNestedAttribute{
+				Description: "Create a time-series collection.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"A collection cannot be converted to or from a time-series collection.",
+						"A collection cannot be converted to or from a time-series collection.",
+					),
+				},
+				Attributes: map[string]schema.Attribute{
+					"time_field": schema.StringAttribute{
+						Description: "Name of the field which contains the date in each time series document.",
 						Required:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"meta_field": schema.StringAttribute{
+						Description: "Name of the field which contains metadata in each time series document.",
+						Optional:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"granularity": schema.StringAttribute{
+						Description: "Granularity of the time series data: seconds, minutes or hours.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
+						Validators: []validator.String{
+							stringvalidator.OneOf("seconds", "minutes", "hours"),
+						},
+					},
+					"expire_after_seconds": schema.Int64Attribute{
+						Description: "Documents ttl in seconds. Can be changed without recreating the collection.",
+						Optional:    true,
+					},
+				},
+			},
+			"clustered_index": schema.SingleNestedAttribute{
+				Description: "Create a clustered collection, clustered on _id. Requires MongoDB 5.3 or later.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"A collection cannot be converted to or from a clustered collection.",
+						"A collection cannot be converted to or from a clustered collection.",
+					),
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("timeseries")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "Name of the clustered index.",
+						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
+					"expire_after_seconds": schema.Int64Attribute{
+						Description: "Documents ttl in seconds, based on the _id value. Can be changed without recreating the collection.",
+						Optional:    true,
+					},
+				},
+			},
+			"capped": schema.BoolAttribute{
+				Description: "Create a capped collection, a fixed-size collection overwriting its oldest documents when full. " +
+					"Requires size_in_bytes. A collection cannot be converted to or from a capped collection.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("size_in_bytes")),
+					boolvalidator.ConflictsWith(path.MatchRoot("timeseries"), path.MatchRoot("clustered_index")),
+				},
+			},
+			"size_in_bytes": schema.Int64Attribute{
+				Description: "Maximum size of the capped collection in bytes. The server rounds it up to a multiple of 256 bytes.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("capped")),
+				},
+			},
+			"max_documents": schema.Int64Attribute{
+				Description: "Maximum number of documents of the capped collection.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("capped")),
 				},
 			},
+			"change_stream_pre_and_post_images": schema.BoolAttribute{
+				Description: "Whether change streams can include the document before and after each change. Requires MongoDB 6.0 or later. " +
+					"Can be changed without recreating the collection.",
+				Optional: true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the collection. MongoDB has no collection description, so it is stored by the provider " +
+					"in the " + metadataCollection + " collection of the database. Can be changed without recreating the collection.",
+				Optional: true,
+			},
+			"index_count": schema.Int64Attribute{
+				Description: "Number of indexes of the collection, including the default _id_ index.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"collation": collationAttribute("Default collation of the collection. Defaults to a collation with the locale set by the provider default_collation_locale, if any."),
 			"id": schema.StringAttribute{
 				Computed:           true,
 				DeprecationMessage: "Just there for compatibility reasons",
 			},
+		}, Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
 	}
 }
 
+// ModifyPlan keeps the namespace consistent with the database and name of the collection.
+func (r *collectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planNamespace(ctx, req, resp, "name")
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *collectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan collectionResourceModel
@@ -117,29 +356,174 @@ func (r *collectionResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
 	databaseName := plan.Database
 	collectionName := plan.Name
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", fmt.Sprintf("collection %s.%s", databaseName, collectionName), timeout)
 
 	tflog.Debug(ctx, fmt.Sprintf("Creating collection %s.%s", databaseName, collectionName))
 
-	db := r.client.Database(databaseName)
+	r.client.checkDatabase(ctx, databaseName, resp.Diagnostics.AddError)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	db := r.client.ddlDatabase(databaseName)
 
 	opts := options.CreateCollection()
 	if plan.Validation != nil {
-		opts.SetValidator(plan.Validation.Validator)
+		validator, err := parseValidator(plan.Validation.Validator)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validation").AtName("validator"),
+				"Invalid validator",
+				"The validator must be a document in MongoDB Extended JSON.\n\nError: "+err.Error(),
+			)
+			return
+		}
+		opts.SetValidator(validator)
+		if plan.Validation.Level.ValueString() != "" {
+			opts.SetValidationLevel(plan.Validation.Level.ValueString())
+		}
+		if plan.Validation.Action.ValueString() != "" {
+			opts.SetValidationAction(plan.Validation.Action.ValueString())
+		}
+	}
+	if plan.TimeSeries != nil {
+		tsOpts := options.TimeSeries().SetTimeField(plan.TimeSeries.TimeField)
+		if plan.TimeSeries.MetaField != nil {
+			tsOpts.SetMetaField(*plan.TimeSeries.MetaField)
+		}
+		if plan.TimeSeries.Granularity.ValueString() != "" {
+			tsOpts.SetGranularity(plan.TimeSeries.Granularity.ValueString())
+		}
+		opts.SetTimeSeriesOptions(tsOpts)
 	}
+	if plan.ClusteredIndex != nil {
+		version, err := serverVersion(ctx, r.client.Client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to get server version",
+				"An unexpected error occurred when getting server version. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+		if !versionAtLeast(version, 5, 3) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("clustered_index"),
+				"Clustered collections not supported",
+				fmt.Sprintf("Clustered collections with ttl require MongoDB 5.3 or later, server version is %s.", formatVersion(version)),
+			)
+			return
+		}
 
-	err := db.CreateCollection(ctx, collectionName, opts)
+		clusteredIndexSpec := bson.D{
+			{Key: "key", Value: bson.D{{Key: "_id", Value: 1}}},
+			{Key: "unique", Value: true},
+		}
+		if plan.ClusteredIndex.Name.ValueString() != "" {
+			clusteredIndexSpec = append(clusteredIndexSpec, bson.E{Key: "name", Value: plan.ClusteredIndex.Name.ValueString()})
+		}
+		opts.SetClusteredIndex(clusteredIndexSpec)
+	}
+	if plan.Capped != nil && *plan.Capped {
+		opts.SetCapped(true)
+		if plan.SizeInBytes != nil {
+			opts.SetSizeInBytes(*plan.SizeInBytes)
+		}
+		if plan.MaxDocuments != nil {
+			opts.SetMaxDocuments(*plan.MaxDocuments)
+		}
+	}
+	if plan.ChangeStreamPreAndPostImages != nil && *plan.ChangeStreamPreAndPostImages {
+		opts.SetChangeStreamPreAndPostImages(bson.D{{Key: "enabled", Value: true}})
+	}
+	if expireAfterSeconds := plan.expireAfterSeconds(); expireAfterSeconds != nil {
+		opts.SetExpireAfterSeconds(*expireAfterSeconds)
+	}
+	if plan.Collation != nil {
+		opts.SetCollation(plan.Collation.toMongoCollation())
+	} else if r.client.defaultCollationLocale != "" {
+		opts.SetCollation(&options.Collation{Locale: r.client.defaultCollationLocale})
+	}
+
+	err = db.CreateCollection(ctx, collectionName, opts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create collection",
 			"An unexpected error occurred when creating collection. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	if plan.Description != nil {
+		err = writeCollectionDescription(ctx, db, collectionName, plan.Description)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to save collection description",
+				"An unexpected error occurred when saving collection description. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Read back the options defaulted by the server
+	if plan.TimeSeries != nil || plan.ClusteredIndex != nil {
+		foundOptions, err := readCollectionOptions(ctx, db, collectionName)
+		if err != nil || foundOptions == nil {
+			resp.Diagnostics.AddError(
+				"Unable to read created collection",
+				"An unexpected error occurred when reading created collection. "+
+					reportFooter()+
+					fmt.Sprintf("Error: %v", err),
+			)
+			return
+		}
+		if plan.TimeSeries != nil && plan.TimeSeries.Granularity.IsUnknown() {
+			plan.TimeSeries.Granularity = foundOptions.toTimeSeries().Granularity
+		}
+		if plan.ClusteredIndex != nil && plan.ClusteredIndex.Name.IsUnknown() {
+			plan.ClusteredIndex.Name = foundOptions.toClusteredIndex().Name
+		}
+	}
+
+	indexCount, err := countIndexes(ctx, db.Collection(collectionName))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list indexes",
+			"An unexpected error occurred when listing indexes. "+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
+	plan.IndexCount = types.Int64Value(indexCount)
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
 	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
 
 	// Set state to fully populated data
@@ -161,34 +545,109 @@ func (r *collectionResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, release := r.client.withSession(ctx)
+	defer release()
+
 	databaseName := state.Database
 	collectionName := state.Name
 
 	tflog.Debug(ctx, fmt.Sprintf("Reading collection %s.%s", databaseName, collectionName))
 
-	db := r.client.Database(databaseName)
-	collections, err := db.ListCollectionNames(ctx, map[string]interface{}{
-		"name": collectionName,
-	})
+	db := r.client.readDatabase(databaseName)
+	foundOptions, err := readCollectionOptions(ctx, db, collectionName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to list collections",
 			"An unexpected error occurred when listing collections. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
-	if len(collections) == 0 {
+	// A collection dropped outside Terraform is planned to be created again.
+	if foundOptions == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Collection %s.%s not found, removing it from the state", databaseName, collectionName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Reading the time-series options back would plan to replace the collection, dropping its measurements.
+	if !state.Id.IsNull() && state.TimeSeries == nil && foundOptions.Type == "timeseries" {
+		resp.Diagnostics.AddError(
+			"Unexpected time-series collection",
+			fmt.Sprintf("Collection %s.%s is declared as a regular collection, but is a time-series collection, "+
+				"likely recreated outside Terraform. To manage it as such, declare its timeseries block, "+
+				"then remove the collection from the state and import it again.", databaseName, collectionName),
+		)
+		return
+	}
+
+	state.Validation, err = foundOptions.toValidation(state.Validation)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Collection not found",
-			fmt.Sprintf("Collection %s.%s does not exist", databaseName, collectionName),
+			"Unable to parse collection validator",
+			"An unexpected error occurred when parsing the collection validator. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.TimeSeries = foundOptions.toTimeSeries()
+	state.ClusteredIndex = foundOptions.toClusteredIndex()
+	state.Capped = readBoolOption(state.Capped, foundOptions.Capped)
+	state.SizeInBytes, state.MaxDocuments = foundOptions.toCappedLimits(state.SizeInBytes)
+	state.ChangeStreamPreAndPostImages = readBoolOption(state.ChangeStreamPreAndPostImages, foundOptions.ChangeStreamPreAndPostImages.Enabled)
+	state.Collation, err = foundOptions.toCollation(state.Collation, r.client.defaultCollationLocale)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse collection collation",
+			"An unexpected error occurred when parsing the collection collation. "+
+				reportFooter()+
+				"Error: "+err.Error(),
 		)
 		return
 	}
 
+	// The metadata collection is only read for collections with a description, and on import to find it, so that
+	// users not granted access to it can still manage collections without description.
+	if state.Description != nil || state.Id.IsNull() {
+		description, err := readCollectionDescription(ctx, db, collectionName)
+		switch {
+		case isUnauthorized(err):
+			resp.Diagnostics.AddWarning(
+				"Unable to read collection description",
+				fmt.Sprintf("The provider user is not granted the find action on %s.%s, the description of collection %s "+
+					"is read as unset.", databaseName, metadataCollection, collectionName),
+			)
+			state.Description = nil
+		case err != nil:
+			resp.Diagnostics.AddError(
+				"Unable to read collection description",
+				"An unexpected error occurred when reading collection description. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		default:
+			state.Description = description
+		}
+	}
+
+	indexCount, err := countIndexes(ctx, db.Collection(collectionName))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list indexes",
+			"An unexpected error occurred when listing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.IndexCount = types.Int64Value(indexCount)
+
 	// Set the state
+	state.Namespace = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
 	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
 
 	// Set refreshed state
@@ -203,10 +662,83 @@ func (r *collectionResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *collectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Updates not supported",
-		"Collection updates are not supported. Changes to collection configuration require recreation.",
-	)
+	var plan, state collectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := plan.Database
+	collectionName := plan.Name
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", fmt.Sprintf("collection %s.%s", databaseName, collectionName), timeout)
+
+	// The options changed in place are all applied by a single collMod.
+	command, err := collModCommand(collectionName, &plan, &state)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("validation").AtName("validator"),
+			"Invalid validator",
+			"The validator must be a document in MongoDB Extended JSON.\n\nError: "+err.Error(),
+		)
+		return
+	}
+	if command != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Updating collection %s.%s with %v", databaseName, collectionName, command))
+
+		err := r.client.Database(databaseName).RunCommand(ctx, command).Err()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update collection",
+				"An unexpected error occurred when updating collection. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(plan.Description, state.Description) {
+		err := writeCollectionDescription(ctx, r.client.ddlDatabase(databaseName), collectionName, plan.Description)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to save collection description",
+				"An unexpected error occurred when saving collection description. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Collection %s.%s updated", databaseName, collectionName))
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -218,23 +750,56 @@ func (r *collectionResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
 	databaseName := state.Database
 	collectionName := state.Name
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", fmt.Sprintf("collection %s.%s", databaseName, collectionName), timeout)
 
 	tflog.Debug(ctx, fmt.Sprintf("Dropping collection %s.%s", databaseName, collectionName))
 
 	db := r.client.Database(databaseName)
-	err := db.Collection(collectionName).Drop(ctx)
+	err = db.Collection(collectionName).Drop(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to drop collection",
 			"An unexpected error occurred when dropping collection. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
+	if state.Description != nil {
+		err = writeCollectionDescription(ctx, db, collectionName, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to remove collection description",
+				"An unexpected error occurred when removing collection description. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Dropped collection %s.%s", databaseName, collectionName))
 }
 
@@ -245,7 +810,7 @@ func (r *collectionResource) ImportState(ctx context.Context, req resource.Impor
 		resp.Diagnostics.AddError(
 			"Invalid id format. Should be <database>.<collection>.",
 			"An unexpected error occurred when importing collection. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
@@ -255,18 +820,359 @@ func (r *collectionResource) ImportState(ctx context.Context, req resource.Impor
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.collection)...)
 }
 
+// parseValidator parses a validator written in MongoDB Extended JSON, either relaxed or canonical, so that
+// typed values like {"$numberLong": "1"} or {"$numberDecimal": "1.5"} keep their BSON type.
+func parseValidator(validator string) (bson.D, error) {
+	var document bson.D
+	err := bson.UnmarshalExtJSON([]byte(validator), false, &document)
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// equal checks whether both validations have the same level, action and validator once parsed, so that
+// validators written with a different formatting or Extended JSON mode are not changed.
+func (v *validation) equal(other *validation) bool {
+	if v == nil || other == nil {
+		return v == other
+	}
+	if v.level() != other.level() || v.action() != other.action() {
+		return false
+	}
+	if v.Validator == other.Validator {
+		return true
+	}
+
+	document, err := parseValidator(v.Validator)
+	if err != nil {
+		return false
+	}
+	otherDocument, err := parseValidator(other.Validator)
+	if err != nil {
+		return false
+	}
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		return false
+	}
+	otherRaw, err := bson.Marshal(otherDocument)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(raw, otherRaw)
+}
+
+// level returns the validation level, strict by default.
+func (v *validation) level() string {
+	if v.Level.ValueString() == "" {
+		return "strict"
+	}
+	return v.Level.ValueString()
+}
+
+// action returns the validation action, error by default.
+func (v *validation) action() string {
+	if v.Action.ValueString() == "" {
+		return "error"
+	}
+	return v.Action.ValueString()
+}
+
+// readCollectionOptions lists the collection with its options, nil if the collection does not exist.
+func readCollectionOptions(ctx context.Context, db *mongo.Database, collectionName string) (*collectionOptions, error) {
+	collections, err := db.ListCollectionSpecifications(ctx, bson.D{{Key: "name", Value: collectionName}})
+	if err != nil {
+		return nil, err
+	}
+	if len(collections) == 0 {
+		return nil, nil
+	}
+
+	var foundOptions collectionOptions
+	err = bson.Unmarshal(collections[0].Options, &foundOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse collection options: %w", err)
+	}
+	foundOptions.Type = collections[0].Type
+	return &foundOptions, nil
+}
+
+// readCollectionDescription reads the description of the collection from the metadata collection, nil if none.
+func readCollectionDescription(ctx context.Context, db *mongo.Database, collectionName string) (*string, error) {
+	var metadata struct {
+		Description *string `bson:"description"`
+	}
+	err := db.Collection(metadataCollection).FindOne(ctx, bson.D{{Key: "_id", Value: collectionName}}).Decode(&metadata)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return metadata.Description, nil
+}
+
+// writeCollectionDescription saves the description of the collection in the metadata collection, removing it when nil.
+func writeCollectionDescription(ctx context.Context, db *mongo.Database, collectionName string, description *string) error {
+	metadata := db.Collection(metadataCollection)
+	if description == nil {
+		_, err := metadata.DeleteOne(ctx, bson.D{{Key: "_id", Value: collectionName}})
+		return err
+	}
+
+	_, err := metadata.UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: collectionName}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "description", Value: *description}}}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// countIndexes counts the indexes of the collection, including the _id_ index.
+func countIndexes(ctx context.Context, collection *mongo.Collection) (int64, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var count int64
+	for cursor.Next(ctx) {
+		count++
+	}
+	return count, cursor.Err()
+}
+
+// toTimeSeries converts the time-series options, nil for other collections.
+func (o *collectionOptions) toTimeSeries() *timeSeries {
+	if o.TimeSeries == nil {
+		return nil
+	}
+
+	return &timeSeries{
+		TimeField:          o.TimeSeries.TimeField,
+		MetaField:          o.TimeSeries.MetaField,
+		Granularity:        types.StringPointerValue(o.TimeSeries.Granularity),
+		ExpireAfterSeconds: o.ExpireAfterSeconds,
+	}
+}
+
+// toClusteredIndex converts the clustered index options, nil for non clustered collections.
+func (o *collectionOptions) toClusteredIndex() *clusteredIndex {
+	var spec struct {
+		Name *string `bson:"name"`
+	}
+	if o.ClusteredIndex.Type != bson.TypeEmbeddedDocument || o.ClusteredIndex.Unmarshal(&spec) != nil {
+		return nil
+	}
+
+	return &clusteredIndex{
+		Name:               types.StringPointerValue(spec.Name),
+		ExpireAfterSeconds: o.ExpireAfterSeconds,
+	}
+}
+
+// toValidation converts the collection validator into Extended JSON. The current validation is kept while it is
+// equivalent, so that the formatting of the configuration is not replaced. Level and action are only read when
+// configured or different from their default.
+func (o *collectionOptions) toValidation(current *validation) (*validation, error) {
+	// An empty validator, as left when the validation is removed, is no validation.
+	if len(o.Validator) <= 5 {
+		return nil, nil
+	}
+
+	// Canonical Extended JSON keeps the BSON types, e.g. of longs, for the validator to be parsed back the same.
+	validator, err := bson.MarshalExtJSON(o.Validator, true, false)
+	if err != nil {
+		return nil, err
+	}
+	found := &validation{Validator: string(validator), Level: types.StringNull(), Action: types.StringNull()}
+	if o.ValidationLevel != "" && (o.ValidationLevel != "strict" || current != nil && !current.Level.IsNull()) {
+		found.Level = types.StringValue(o.ValidationLevel)
+	}
+	if o.ValidationAction != "" && (o.ValidationAction != "error" || current != nil && !current.Action.IsNull()) {
+		found.Action = types.StringValue(o.ValidationAction)
+	}
+
+	if current.equal(found) {
+		return current, nil
+	}
+	return found, nil
+}
+
+// toCappedLimits returns the size and maximum number of documents of a capped collection. The size is stored by
+// the server rounded up to a multiple of 256 bytes, the current size is kept when it rounds to the same value.
+func (o *collectionOptions) toCappedLimits(currentSize *int64) (*int64, *int64) {
+	if !o.Capped {
+		return nil, nil
+	}
+
+	size := o.Size
+	if size != nil && currentSize != nil && *size >= *currentSize && *size-*currentSize < 256 {
+		size = currentSize
+	}
+	// A max of 0 means no limit.
+	maxDocuments := o.Max
+	if maxDocuments != nil && *maxDocuments <= 0 {
+		maxDocuments = nil
+	}
+	return size, maxDocuments
+}
+
+// toCollation converts the collection collation. The current collation is kept while the server collation has
+// its options, the server filling in the options left to their default, and so is no collation while the
+// server collation is the one given by the provider default locale. The simple locale is stored as no collation.
+func (o *collectionOptions) toCollation(current *collation, defaultLocale string) (*collation, error) {
+	found, err := fromMongoCollation(o.Collation)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case current == nil && found != nil && found.Locale == defaultLocale:
+		return nil, nil
+	case current != nil && found == nil && current.Locale == "simple":
+		return current, nil
+	case current.matches(found):
+		return current, nil
+	}
+	return found, nil
+}
+
+// collModCommand assembles the collMod command applying the changes from state to plan of the options which can
+// be changed in place, nil when none of them changed. Changes to other options are handled by recreation.
+func collModCommand(collectionName string, plan *collectionResourceModel, state *collectionResourceModel) (bson.D, error) {
+	command := bson.D{{Key: "collMod", Value: collectionName}}
+
+	// Validators only differing in their formatting need no update. Removing the validation block removes the
+	// validator and restores the default level and action.
+	if !plan.Validation.equal(state.Validation) {
+		validator, level, action := bson.D{}, "strict", "error"
+		if plan.Validation != nil {
+			var err error
+			validator, err = parseValidator(plan.Validation.Validator)
+			if err != nil {
+				return nil, err
+			}
+			level = plan.Validation.level()
+			action = plan.Validation.action()
+		}
+		command = append(command,
+			bson.E{Key: "validator", Value: validator},
+			bson.E{Key: "validationLevel", Value: level},
+			bson.E{Key: "validationAction", Value: action},
+		)
+	}
+
+	// Only the ttl of a time-series or clustered collection can be changed.
+	if !reflect.DeepEqual(plan.expireAfterSeconds(), state.expireAfterSeconds()) {
+		var expireAfterSeconds interface{} = "off"
+		if plan.expireAfterSeconds() != nil {
+			expireAfterSeconds = *plan.expireAfterSeconds()
+		}
+		command = append(command, bson.E{Key: "expireAfterSeconds", Value: expireAfterSeconds})
+	}
+
+	enabled := plan.ChangeStreamPreAndPostImages != nil && *plan.ChangeStreamPreAndPostImages
+	if enabled != (state.ChangeStreamPreAndPostImages != nil && *state.ChangeStreamPreAndPostImages) {
+		command = append(command, bson.E{Key: "changeStreamPreAndPostImages", Value: bson.D{{Key: "enabled", Value: enabled}}})
+	}
+
+	if len(command) == 1 {
+		return nil, nil
+	}
+	return command, nil
+}
+
+// expireAfterSeconds returns the collection ttl, which is declared in the time-series or clustered index block.
+func (m *collectionResourceModel) expireAfterSeconds() *int64 {
+	if m.TimeSeries != nil {
+		return m.TimeSeries.ExpireAfterSeconds
+	}
+	if m.ClusteredIndex != nil {
+		return m.ClusteredIndex.ExpireAfterSeconds
+	}
+	return nil
+}
+
+// planNamespace fills the database and collection attributes of the plan from the namespace attribute when it is
+// configured, and the namespace from the database and collection attributes otherwise.
+func planNamespace(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, collectionAttribute string) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	namespacePath := path.Root("namespace")
+	databasePath := path.Root("database")
+	collectionPath := path.Root(collectionAttribute)
+
+	var namespace types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, namespacePath, &namespace)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !namespace.IsNull() {
+		database, collection := types.StringUnknown(), types.StringUnknown()
+		if !namespace.IsUnknown() {
+			id, err := parseCollectionId(namespace.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					namespacePath,
+					"Invalid namespace",
+					"The namespace must be formatted as <database>.<collection>.\n\n"+
+						"Error: "+err.Error(),
+				)
+				return
+			}
+			database, collection = types.StringValue(id.database), types.StringValue(id.collection)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, databasePath, database)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, collectionPath, collection)...)
+
+		// The database and collection are set after their own plan modifiers ran, so moving to another
+		// namespace requires the replacement here.
+		if !req.State.Raw.IsNull() {
+			for attributePath, planned := range map[string]types.String{"database": database, collectionAttribute: collection} {
+				var current types.String
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attributePath), &current)...)
+				if !planned.Equal(current) {
+					resp.RequiresReplace = append(resp.RequiresReplace, path.Root(attributePath))
+				}
+			}
+		}
+		return
+	}
+
+	var database, collection types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, databasePath, &database)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, collectionPath, &collection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace = types.StringUnknown()
+	if !database.IsUnknown() && !collection.IsUnknown() {
+		namespace = types.StringValue(fmt.Sprintf("%s.%s", database.ValueString(), collection.ValueString()))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, namespacePath, namespace)...)
+}
+
 type collectionId struct {
 	database   string
 	collection string
 }
 
+// Parse an id formatted as <database>.<collection>. Database names cannot contain dots, collection names can.
 func parseCollectionId(id string) (*collectionId, error) {
-	parts := strings.Split(id, ".")
-	if len(parts) != 2 {
+	database, collection, found := strings.Cut(id, ".")
+	if !found || database == "" || collection == "" {
 		return nil, fmt.Errorf("invalid id format: %s", id)
 	}
 	return &collectionId{
-		database:   parts[0],
-		collection: parts[1],
+		database:   database,
+		collection: collection,
 	}, nil
 }
@@ -1,9 +1,28 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 )
 
 func TestAccCollectionResource(t *testing.T) {
@@ -30,3 +49,1513 @@ resource "mongodb_collection" "test" {
 		},
 	})
 }
+
+func TestAccCollectionResourceDropped(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_collection" "dropped" {
+	database = "test_collection_dropped"
+	name = "events"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// A collection dropped outside Terraform is created again rather than failing the plan.
+			{
+				PreConfig: func() {
+					err := testAccClient(t).Database("test_collection_dropped").Collection("events").Drop(context.Background())
+					if err != nil {
+						t.Fatalf("Unable to drop collection: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.dropped", plancheck.ResourceActionCreate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestParseValidator(t *testing.T) {
+	validator, err := parseValidator(`{"$jsonSchema": {"bsonType": "object", "properties": {
+		"count": {"bsonType": "long", "minimum": {"$numberLong": "1"}},
+		"price": {"bsonType": "decimal", "maximum": {"$numberDecimal": "9.99"}}
+	}}}`)
+	if err != nil {
+		t.Fatalf("Unable to parse validator: %v", err)
+	}
+
+	raw, _ := bson.Marshal(validator)
+	properties := bson.Raw(raw).Lookup("$jsonSchema", "properties")
+	if value := properties.Document().Lookup("count", "minimum"); value.Type != bson.TypeInt64 {
+		t.Errorf("Expected the long minimum to be a long, got %s", value.Type)
+	}
+	if value := properties.Document().Lookup("price", "maximum"); value.Type != bson.TypeDecimal128 {
+		t.Errorf("Expected the decimal maximum to be a decimal, got %s", value.Type)
+	}
+	if value := properties.Document().Lookup("count", "bsonType"); value.StringValue() != "long" {
+		t.Errorf("Expected the long bson type to be kept, got %s", value)
+	}
+
+	if _, err := parseValidator(`{"$jsonSchema": `); err == nil {
+		t.Error("Expected an error for an invalid validator")
+	}
+}
+
+func TestValidationEqual(t *testing.T) {
+	compact := &validation{Validator: `{"$jsonSchema":{"bsonType":"object","properties":{"count":{"minimum":1}}}}`}
+
+	cases := []struct {
+		name  string
+		other *validation
+		equal bool
+	}{
+		{"same", &validation{Validator: compact.Validator}, true},
+		{"reformatted", &validation{Validator: `{
+			"$jsonSchema": {
+				"bsonType": "object",
+				"properties": { "count": { "minimum": 1 } }
+			}
+		}`}, true},
+		{"canonical extended json", &validation{Validator: `{"$jsonSchema":{"bsonType":"object","properties":{"count":{"minimum":{"$numberInt":"1"}}}}}`}, true},
+		{"other type", &validation{Validator: `{"$jsonSchema":{"bsonType":"object","properties":{"count":{"minimum":{"$numberLong":"1"}}}}}`}, false},
+		{"other value", &validation{Validator: `{"$jsonSchema":{"bsonType":"object","properties":{"count":{"minimum":2}}}}`}, false},
+		{"invalid", &validation{Validator: `{"$jsonSchema":`}, false},
+		{"none", nil, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if equal := compact.equal(c.other); equal != c.equal {
+				t.Errorf("Expected equal %t, got %t", c.equal, equal)
+			}
+		})
+	}
+
+	var none *validation
+	if !none.equal(nil) {
+		t.Error("Expected no validations to be equal")
+	}
+}
+
+func TestAccCollectionResourceReformattedValidator(t *testing.T) {
+	readValidator := func() (bson.Raw, error) {
+		specs, err := testAccClient(t).Database("test_validator").ListCollectionSpecifications(context.Background(), bson.D{{Key: "name", Value: "reformatted"}})
+		if err != nil {
+			return nil, err
+		}
+		if len(specs) == 0 {
+			return nil, fmt.Errorf("collection reformatted not found")
+		}
+		return specs[0].Options.Lookup("validator").Document(), nil
+	}
+	var created bson.Raw
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_validator"
+	name = "reformatted"
+	validation = {
+		validator = jsonencode({ "$jsonSchema" = { bsonType = "object", required = ["name"] } })
+	}
+}
+`,
+				Check: func(_ *terraform.State) error {
+					var err error
+					created, err = readValidator()
+					return err
+				},
+			},
+			// The same validator written differently is saved in the state without running collMod, nor
+			// recreating the collection.
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_validator"
+	name = "reformatted"
+	validation = {
+		validator = <<-EOT
+			{
+				"$jsonSchema": {
+					"bsonType": "object",
+					"required": [ "name" ]
+				}
+			}
+		EOT
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					validator, err := readValidator()
+					if err != nil {
+						return err
+					}
+					if !bytes.Equal(validator, created) {
+						return fmt.Errorf("expected the validator to be unchanged, got %s", validator)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceValidationUpdate(t *testing.T) {
+	readOptions := func() (bson.Raw, error) {
+		specs, err := testAccClient(t).Database("test_validator").ListCollectionSpecifications(context.Background(), bson.D{{Key: "name", Value: "updated"}})
+		if err != nil {
+			return nil, err
+		}
+		if len(specs) == 0 {
+			return nil, fmt.Errorf("collection updated not found")
+		}
+		return specs[0].Options, nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_validator"
+	name = "updated"
+	validation = {
+		validator = jsonencode({ name = { "$exists" = true } })
+	}
+}
+`,
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_validator"
+	name = "updated"
+	validation = {
+		validator = jsonencode({ total = { "$gte" = 0 } })
+		level = "moderate"
+		action = "warn"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					opts, err := readOptions()
+					if err != nil {
+						return err
+					}
+					if _, err = opts.LookupErr("validator", "total"); err != nil {
+						return fmt.Errorf("expected the validator to be updated, got %s", opts)
+					}
+					if opts.Lookup("validationLevel").StringValue() != "moderate" || opts.Lookup("validationAction").StringValue() != "warn" {
+						return fmt.Errorf("expected the moderate level and warn action, got %s", opts)
+					}
+					return nil
+				},
+			},
+			// Removing the validation removes the validator in place.
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_validator"
+	name = "updated"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					opts, err := readOptions()
+					if err != nil {
+						return err
+					}
+					if validator, err := opts.LookupErr("validator"); err == nil && len(validator.Document()) > 5 {
+						return fmt.Errorf("expected no validator, got %s", validator)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceValidatorDrift(t *testing.T) {
+	ctx := context.Background()
+	config := providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_validator"
+	name = "drift"
+	validation = {
+		validator = jsonencode({ name = { "$exists" = true } })
+	}
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// The validator edited outside Terraform is planned back to the configured one, in place.
+			{
+				PreConfig: func() {
+					err := testAccClient(t).Database("test_validator").RunCommand(ctx, bson.D{
+						{Key: "collMod", Value: "drift"},
+						{Key: "validator", Value: bson.D{{Key: "email", Value: bson.D{{Key: "$exists", Value: true}}}}},
+						{Key: "validationAction", Value: "warn"},
+					}).Err()
+					if err != nil {
+						t.Fatalf("Unable to change the validator: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectNonEmptyPlan(),
+						plancheck.ExpectResourceAction("mongodb_collection.test", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					found, err := readCollectionOptions(ctx, testAccClient(t).Database("test_validator"), "drift")
+					if err != nil {
+						return err
+					}
+					if _, err = found.Validator.LookupErr("name"); err != nil || found.ValidationAction != "error" {
+						return fmt.Errorf("expected the validator to be reconciled, got %s with action %s", found.Validator, found.ValidationAction)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceValidationAction(t *testing.T) {
+	ctx := context.Background()
+	config := func(action string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "mongodb_collection" "test" {
+	database = "test_validator"
+	name = "action"
+	validation = {
+		validator = jsonencode({ name = { "$exists" = true } })
+		action = %q
+	}
+}
+`, action)
+	}
+	insertInvalid := func() error {
+		_, err := testAccClient(t).Database("test_validator").Collection("action").InsertOne(ctx, bson.D{{Key: "other", Value: 1}})
+		return err
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// With the warn action, invalid documents are only logged by the server.
+			{
+				Config: config("warn"),
+				Check: func(_ *terraform.State) error {
+					if err := insertInvalid(); err != nil {
+						return fmt.Errorf("expected the invalid document to be inserted, got %v", err)
+					}
+					return nil
+				},
+			},
+			{
+				Config: config("error"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					var serverErr mongo.ServerError
+					if err := insertInvalid(); !errors.As(err, &serverErr) || !serverErr.HasErrorCode(121) {
+						return fmt.Errorf("expected the invalid document to fail validation, got %v", err)
+					}
+					return nil
+				},
+			},
+			// The action changed outside Terraform is planned back.
+			{
+				PreConfig: func() {
+					err := testAccClient(t).Database("test_validator").RunCommand(ctx, bson.D{
+						{Key: "collMod", Value: "action"},
+						{Key: "validationAction", Value: "warn"},
+					}).Err()
+					if err != nil {
+						t.Fatalf("Unable to change the validation action: %v", err)
+					}
+				},
+				Config: config("error"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.test", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceTypedValidator(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_validator"
+	name = "typed"
+	validation = {
+		validator = jsonencode({
+			"$jsonSchema" = {
+				bsonType = "object"
+				properties = {
+					count = { bsonType = "long", minimum = { "$numberLong" = "1" } }
+					price = { bsonType = "decimal", maximum = { "$numberDecimal" = "9.99" } }
+				}
+			}
+		})
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceTimeSeries(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "timeseries" {
+	database = "test_db"
+	name = "test_timeseries"
+	timeseries = {
+		time_field = "timestamp"
+		meta_field = "metadata"
+		granularity = "minutes"
+		expire_after_seconds = 3600
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.timeseries", "timeseries.time_field", "timestamp"),
+					resource.TestCheckResourceAttr("mongodb_collection.timeseries", "timeseries.meta_field", "metadata"),
+					resource.TestCheckResourceAttr("mongodb_collection.timeseries", "timeseries.granularity", "minutes"),
+					resource.TestCheckResourceAttr("mongodb_collection.timeseries", "timeseries.expire_after_seconds", "3600"),
+					resource.TestCheckNoResourceAttr("mongodb_collection.timeseries", "clustered_index"),
+				),
+			},
+			{
+				ResourceName:      "mongodb_collection.timeseries",
+				ImportStateId:     "test_db.test_timeseries",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing the ttl must not replace the collection
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "timeseries" {
+	database = "test_db"
+	name = "test_timeseries"
+	timeseries = {
+		time_field = "timestamp"
+		meta_field = "metadata"
+		granularity = "minutes"
+		expire_after_seconds = 7200
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.timeseries", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.timeseries", "timeseries.expire_after_seconds", "7200"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceUnexpectedTimeSeries(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_collection" "regular" {
+	database = "test_db"
+	name = "test_unexpected_timeseries"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckNoResourceAttr("mongodb_collection.regular", "timeseries"),
+			},
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					db := testAccClient(t).Database("test_db")
+					if err := db.Collection("test_unexpected_timeseries").Drop(ctx); err != nil {
+						t.Fatalf("Unable to drop collection: %v", err)
+					}
+					err := db.CreateCollection(ctx, "test_unexpected_timeseries", options.CreateCollection().
+						SetTimeSeriesOptions(options.TimeSeries().SetTimeField("timestamp")))
+					if err != nil {
+						t.Fatalf("Unable to create time-series collection: %v", err)
+					}
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Unexpected time-series collection`),
+			},
+			// Once declared, the time-series collection is imported back.
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "regular" {
+	database = "test_db"
+	name = "test_unexpected_timeseries"
+	timeseries = {
+		time_field = "timestamp"
+	}
+}
+`,
+				ResourceName:  "mongodb_collection.regular",
+				ImportStateId: "test_db.test_unexpected_timeseries",
+				ImportState:   true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["timeseries.time_field"] != "timestamp" {
+						return fmt.Errorf("expected the time-series options to be imported, got %v", states)
+					}
+					return nil
+				},
+			},
+			// Back to a regular collection for the state to be destroyed.
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					db := testAccClient(t).Database("test_db")
+					if err := db.Collection("test_unexpected_timeseries").Drop(ctx); err != nil {
+						t.Fatalf("Unable to drop collection: %v", err)
+					}
+					if err := db.CreateCollection(ctx, "test_unexpected_timeseries"); err != nil {
+						t.Fatalf("Unable to create collection: %v", err)
+					}
+				},
+				Config: config,
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceClusteredIndex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckServerVersion(t, 5, 3) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "clustered" {
+	database = "test_db"
+	name = "test_clustered"
+	clustered_index = {
+		name = "clustered_id"
+		expire_after_seconds = 3600
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.clustered", "clustered_index.name", "clustered_id"),
+					resource.TestCheckResourceAttr("mongodb_collection.clustered", "clustered_index.expire_after_seconds", "3600"),
+					resource.TestCheckNoResourceAttr("mongodb_collection.clustered", "timeseries"),
+				),
+			},
+			{
+				ResourceName:      "mongodb_collection.clustered",
+				ImportStateId:     "test_db.test_clustered",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "clustered" {
+	database = "test_db"
+	name = "test_clustered"
+	clustered_index = {
+		name = "clustered_id"
+		expire_after_seconds = 60
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.clustered", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.clustered", "clustered_index.expire_after_seconds", "60"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceClusteredTTLDrift(t *testing.T) {
+	ctx := context.Background()
+	config := providerConfig + `
+resource "mongodb_collection" "clustered" {
+	database = "test_db"
+	name = "test_clustered_drift"
+	clustered_index = {
+		expire_after_seconds = 3600
+	}
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckServerVersion(t, 5, 3) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// The ttl changed outside Terraform is planned back to the configured one, in place.
+			{
+				PreConfig: func() {
+					err := testAccClient(t).Database("test_db").RunCommand(ctx, bson.D{
+						{Key: "collMod", Value: "test_clustered_drift"},
+						{Key: "expireAfterSeconds", Value: 60},
+					}).Err()
+					if err != nil {
+						t.Fatalf("Unable to change the collection ttl: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectNonEmptyPlan(),
+						plancheck.ExpectResourceAction("mongodb_collection.clustered", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					found, err := readCollectionOptions(ctx, testAccClient(t).Database("test_db"), "test_clustered_drift")
+					if err != nil {
+						return err
+					}
+					if found == nil || found.ExpireAfterSeconds == nil || *found.ExpireAfterSeconds != 3600 {
+						return fmt.Errorf("expected the ttl to be reconciled to 3600, got %+v", found)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceBatchedUpdate(t *testing.T) {
+	readOptions := func() (*collectionOptions, error) {
+		return readCollectionOptions(context.Background(), testAccClient(t).Database("test_db"), "test_batched")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckServerVersion(t, 6, 0) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "batched" {
+	database = "test_db"
+	name = "test_batched"
+	clustered_index = {
+		expire_after_seconds = 3600
+	}
+}
+`,
+				Check: resource.TestCheckNoResourceAttr("mongodb_collection.batched", "change_stream_pre_and_post_images"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "batched" {
+	database = "test_db"
+	name = "test_batched"
+	clustered_index = {
+		expire_after_seconds = 60
+	}
+	change_stream_pre_and_post_images = true
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.batched", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					found, err := readOptions()
+					if err != nil {
+						return err
+					}
+					if found == nil || found.ExpireAfterSeconds == nil || *found.ExpireAfterSeconds != 60 || !found.ChangeStreamPreAndPostImages.Enabled {
+						return fmt.Errorf("expected both options to be updated, got %+v", found)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceDescription(t *testing.T) {
+	readDescription := func() (*string, error) {
+		return readCollectionDescription(context.Background(), testAccClient(t).Database("test_db"), "test_described")
+	}
+	checkDescription := func(want string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			description, err := readDescription()
+			if err != nil {
+				return err
+			}
+			if (want == "") != (description == nil) || (description != nil && *description != want) {
+				return fmt.Errorf("expected the stored description %q, got %v", want, description)
+			}
+			return nil
+		}
+	}
+	config := func(description string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "mongodb_collection" "described" {
+	database = "test_db"
+	name = "test_described"
+	%s
+}
+`, description)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkDescription(""),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`description = "Orders of the shop"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.described", "description", "Orders of the shop"),
+					checkDescription("Orders of the shop"),
+				),
+			},
+			{
+				ResourceName:      "mongodb_collection.described",
+				ImportStateId:     "test_db.test_described",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(`description = "Paid orders of the shop"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.described", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkDescription("Paid orders of the shop"),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mongodb_collection.described", "description"),
+					checkDescription(""),
+				),
+			},
+		},
+	})
+}
+
+// A user without access to the metadata collection manages the collections without description.
+func TestAccCollectionResourceDescriptionUnauthorized(t *testing.T) {
+	config := `
+provider "mongodb" {
+  host = "localhost"
+  port = "27017"
+  username = "collection_admin"
+  password = "password"
+  auth_database = "test_db_admin"
+}
+
+resource "mongodb_collection" "undescribed" {
+	database = "test_db_admin"
+	name = "events"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			db := testAccClient(t).Database("test_db_admin")
+			if err := db.Drop(context.Background()); err != nil {
+				t.Fatalf("Unable to drop database: %v", err)
+			}
+			err := db.RunCommand(context.Background(), bson.D{
+				{Key: "createUser", Value: "collection_admin"},
+				{Key: "pwd", Value: "password"},
+				{Key: "roles", Value: bson.A{bson.D{{Key: "role", Value: "dbAdmin"}, {Key: "db", Value: "test_db_admin"}}}},
+			}).Err()
+			if err != nil {
+				t.Fatalf("Unable to create user: %v", err)
+			}
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			return testAccClient(t).Database("test_db_admin").RunCommand(context.Background(), bson.D{{Key: "dropUser", Value: "collection_admin"}}).Err()
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckNoResourceAttr("mongodb_collection.undescribed", "description"),
+			},
+			// On import, the description which cannot be read is read as unset.
+			{
+				ResourceName:      "mongodb_collection.undescribed",
+				ImportStateId:     "test_db_admin.events",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestCollectionOptionsTimeSeries(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{
+		{Key: "timeseries", Value: bson.D{
+			{Key: "timeField", Value: "timestamp"},
+			{Key: "granularity", Value: "seconds"},
+		}},
+		{Key: "clusteredIndex", Value: true},
+		{Key: "expireAfterSeconds", Value: int64(60)},
+	})
+
+	var opts collectionOptions
+	if err := bson.Unmarshal(raw, &opts); err != nil {
+		t.Fatalf("Unable to parse options: %v", err)
+	}
+
+	ts := opts.toTimeSeries()
+	if ts == nil || ts.TimeField != "timestamp" || ts.MetaField != nil || ts.Granularity.ValueString() != "seconds" || *ts.ExpireAfterSeconds != 60 {
+		t.Fatalf("Unexpected time-series options %+v", ts)
+	}
+	if ci := opts.toClusteredIndex(); ci != nil {
+		t.Fatalf("Expected no clustered index for a time-series collection, got %+v", ci)
+	}
+}
+
+func TestCollectionOptionsClusteredIndex(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{
+		{Key: "clusteredIndex", Value: bson.D{
+			{Key: "v", Value: 2},
+			{Key: "key", Value: bson.D{{Key: "_id", Value: 1}}},
+			{Key: "name", Value: "_id_"},
+			{Key: "unique", Value: true},
+		}},
+		{Key: "expireAfterSeconds", Value: int32(3600)},
+	})
+
+	var opts collectionOptions
+	if err := bson.Unmarshal(raw, &opts); err != nil {
+		t.Fatalf("Unable to parse options: %v", err)
+	}
+
+	ci := opts.toClusteredIndex()
+	if ci == nil || ci.Name.ValueString() != "_id_" || *ci.ExpireAfterSeconds != 3600 {
+		t.Fatalf("Unexpected clustered index options %+v", ci)
+	}
+	if ts := opts.toTimeSeries(); ts != nil {
+		t.Fatalf("Expected no time-series options, got %+v", ts)
+	}
+}
+
+func TestCollectionOptionsCappedLimits(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{
+		{Key: "capped", Value: true},
+		{Key: "size", Value: int64(1024)},
+		{Key: "max", Value: int32(100)},
+	})
+
+	var opts collectionOptions
+	if err := bson.Unmarshal(raw, &opts); err != nil {
+		t.Fatalf("Unable to parse options: %v", err)
+	}
+
+	configured := int64(1000)
+	size, maxDocuments := opts.toCappedLimits(&configured)
+	if size != &configured || *maxDocuments != 100 {
+		t.Errorf("Expected the configured size rounded up by the server to be kept, got %d and %d", *size, *maxDocuments)
+	}
+
+	configured = 512
+	if size, _ = opts.toCappedLimits(&configured); *size != 1024 {
+		t.Errorf("Expected the size read to replace a size changed outside Terraform, got %d", *size)
+	}
+
+	if size, _ = opts.toCappedLimits(nil); *size != 1024 {
+		t.Errorf("Expected the size read on import, got %d", *size)
+	}
+
+	opts = collectionOptions{}
+	if size, maxDocuments = opts.toCappedLimits(&configured); size != nil || maxDocuments != nil {
+		t.Errorf("Expected no limits for a collection which is not capped, got %v and %v", size, maxDocuments)
+	}
+}
+
+func TestAccCollectionResourceCapped(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "capped" {
+	database = "test_db"
+	name = "test_capped"
+	capped = true
+	size_in_bytes = 100000
+	max_documents = 100
+}
+`,
+				// The size is rounded up by the server, which must not show as a diff.
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.capped", "capped", "true"),
+					resource.TestCheckResourceAttr("mongodb_collection.capped", "size_in_bytes", "100000"),
+					resource.TestCheckResourceAttr("mongodb_collection.capped", "max_documents", "100"),
+					func(_ *terraform.State) error {
+						capped, err := testAccClient(t).Database("test_db").
+							RunCommand(context.Background(), bson.D{{Key: "collStats", Value: "test_capped"}}).Raw()
+						if err != nil {
+							return err
+						}
+						if !capped.Lookup("capped").Boolean() {
+							return fmt.Errorf("expected the collection to be capped, got %s", capped)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "mongodb_collection.capped",
+				ImportStateId:     "test_db.test_capped",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The size imported is the one rounded up by the server.
+				ImportStateVerifyIgnore: []string{"size_in_bytes"},
+			},
+			// A collection cannot be resized in place.
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "capped" {
+	database = "test_db"
+	name = "test_capped"
+	capped = true
+	size_in_bytes = 100000
+	max_documents = 200
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.capped", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mongodb_collection.capped", "max_documents", "200"),
+			},
+		},
+	})
+}
+
+func TestCollectionOptionsValidation(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{
+		{Key: "validator", Value: bson.D{{Key: "count", Value: bson.D{{Key: "$gte", Value: int64(1)}}}}},
+		{Key: "validationLevel", Value: "strict"},
+		{Key: "validationAction", Value: "warn"},
+	})
+	var opts collectionOptions
+	if err := bson.Unmarshal(raw, &opts); err != nil {
+		t.Fatalf("Unable to parse options: %v", err)
+	}
+
+	// The configured validation is kept while equivalent.
+	current := &validation{
+		Validator: `{ "count": { "$gte": { "$numberLong": "1" } } }`,
+		Level:     types.StringNull(),
+		Action:    types.StringValue("warn"),
+	}
+	found, err := opts.toValidation(current)
+	if err != nil || found != current {
+		t.Errorf("Expected the current validation to be kept, got %+v and %v", found, err)
+	}
+
+	// A validator changed outside Terraform is read in canonical Extended JSON, the default level is left unset.
+	current = &validation{Validator: `{"count": {"$gte": 2}}`, Level: types.StringNull(), Action: types.StringValue("warn")}
+	found, err = opts.toValidation(current)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := &validation{
+		Validator: `{"count":{"$gte":{"$numberLong":"1"}}}`,
+		Level:     types.StringNull(),
+		Action:    types.StringValue("warn"),
+	}
+	if !reflect.DeepEqual(found, want) {
+		t.Errorf("Expected %+v, got %+v", want, found)
+	}
+	if !found.equal(&validation{Validator: `{"count": {"$gte": {"$numberLong": "1"}}}`, Action: types.StringValue("warn")}) {
+		t.Errorf("Expected the validator read to parse back to the same document")
+	}
+
+	// A removed validator is read as no validation.
+	raw, _ = bson.Marshal(bson.D{{Key: "validator", Value: bson.D{}}})
+	opts = collectionOptions{}
+	_ = bson.Unmarshal(raw, &opts)
+	if found, err = opts.toValidation(current); found != nil || err != nil {
+		t.Errorf("Expected no validation, got %+v and %v", found, err)
+	}
+}
+
+func TestCollModCommand(t *testing.T) {
+	ttl := func(v int64) *int64 { return &v }
+	enabled := true
+
+	state := collectionResourceModel{ClusteredIndex: &clusteredIndex{ExpireAfterSeconds: ttl(3600)}}
+	if command, _ := collModCommand("test", &state, &state); command != nil {
+		t.Errorf("Expected no command without changes, got %v", command)
+	}
+
+	plan := collectionResourceModel{ClusteredIndex: &clusteredIndex{ExpireAfterSeconds: ttl(60)}, ChangeStreamPreAndPostImages: &enabled}
+	want := bson.D{
+		{Key: "collMod", Value: "test"},
+		{Key: "expireAfterSeconds", Value: int64(60)},
+		{Key: "changeStreamPreAndPostImages", Value: bson.D{{Key: "enabled", Value: true}}},
+	}
+	if command, _ := collModCommand("test", &plan, &state); !reflect.DeepEqual(command, want) {
+		t.Errorf("Expected a single command %v, got %v", want, command)
+	}
+
+	disabled := false
+	plan = collectionResourceModel{ChangeStreamPreAndPostImages: &disabled}
+	state = collectionResourceModel{ClusteredIndex: &clusteredIndex{ExpireAfterSeconds: ttl(60)}}
+	want = bson.D{
+		{Key: "collMod", Value: "test"},
+		{Key: "expireAfterSeconds", Value: "off"},
+	}
+	if command, _ := collModCommand("test", &plan, &state); !reflect.DeepEqual(command, want) {
+		t.Errorf("Expected the ttl to be turned off, got %v", command)
+	}
+}
+
+func TestCollModCommandValidation(t *testing.T) {
+	state := collectionResourceModel{Validation: &validation{Validator: `{"name": {"$exists": true}}`}}
+
+	// The level and action default to strict and error.
+	plan := collectionResourceModel{Validation: &validation{Validator: `{ "name": { "$exists": true } }`, Level: types.StringValue("strict")}}
+	if command, err := collModCommand("test", &plan, &state); command != nil || err != nil {
+		t.Errorf("Expected no command for an equivalent validation, got %v and %v", command, err)
+	}
+
+	plan = collectionResourceModel{Validation: &validation{
+		Validator: `{"total": {"$gte": 0}}`,
+		Level:     types.StringValue("moderate"),
+		Action:    types.StringValue("warn"),
+	}}
+	want := bson.D{
+		{Key: "collMod", Value: "test"},
+		{Key: "validator", Value: bson.D{{Key: "total", Value: bson.D{{Key: "$gte", Value: int32(0)}}}}},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "warn"},
+	}
+	if command, err := collModCommand("test", &plan, &state); err != nil || !reflect.DeepEqual(command, want) {
+		t.Errorf("Expected %v, got %v and %v", want, command, err)
+	}
+
+	want = bson.D{
+		{Key: "collMod", Value: "test"},
+		{Key: "validator", Value: bson.D{}},
+		{Key: "validationLevel", Value: "strict"},
+		{Key: "validationAction", Value: "error"},
+	}
+	if command, err := collModCommand("test", &collectionResourceModel{}, &state); err != nil || !reflect.DeepEqual(command, want) {
+		t.Errorf("Expected the validator to be removed, got %v and %v", command, err)
+	}
+
+	plan = collectionResourceModel{Validation: &validation{Validator: `{"total": `}}
+	if _, err := collModCommand("test", &plan, &state); err == nil {
+		t.Error("Expected an error for an invalid validator")
+	}
+}
+
+func TestCollectionOptionsCollation(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{
+		{Key: "collation", Value: bson.D{
+			{Key: "locale", Value: "fr"},
+			{Key: "caseLevel", Value: false},
+			{Key: "strength", Value: int32(2)},
+		}},
+	})
+
+	var opts collectionOptions
+	if err := bson.Unmarshal(raw, &opts); err != nil {
+		t.Fatalf("Unable to parse options: %v", err)
+	}
+
+	locale := func(co *collation) string {
+		if co == nil {
+			return ""
+		}
+		return co.Locale
+	}
+	strength := 2
+	otherStrength := 3
+	cases := []struct {
+		current       *collation
+		defaultLocale string
+		wantLocale    string
+		wantCurrent   bool
+	}{
+		{nil, "", "fr", false},
+		{nil, "fr", "", true},
+		{&collation{Locale: "fr"}, "", "fr", true},
+		{&collation{Locale: "fr", Strength: &strength}, "", "fr", true},
+		{&collation{Locale: "fr", Strength: &otherStrength}, "", "fr", false},
+		{&collation{Locale: "de"}, "", "fr", false},
+	}
+	for _, c := range cases {
+		found, err := opts.toCollation(c.current, c.defaultLocale)
+		if err != nil {
+			t.Fatalf("Unable to convert collation: %v", err)
+		}
+		if locale(found) != c.wantLocale || (found == c.current) != c.wantCurrent {
+			t.Errorf("Unexpected collation %+v for the current collation %+v and default locale %q", found, c.current, c.defaultLocale)
+		}
+	}
+
+	var simple collectionOptions
+	current := &collation{Locale: "simple"}
+	if found, err := simple.toCollation(current, ""); err != nil || found != current {
+		t.Errorf("Expected the simple collation to be kept, got %+v, %v", found, err)
+	}
+	if found, err := simple.toCollation(&collation{Locale: "fr"}, ""); err != nil || found != nil {
+		t.Errorf("Expected no collation, got %+v, %v", found, err)
+	}
+}
+
+func TestAccReadCollectionOptionsExactName(t *testing.T) {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+
+	ctx := context.Background()
+	db := testAccClient(t).Database("test_exact_name")
+	if err := db.Drop(ctx); err != nil {
+		t.Fatalf("Unable to drop database: %v", err)
+	}
+	if err := db.CreateCollection(ctx, "test2"); err != nil {
+		t.Fatalf("Unable to create collection: %v", err)
+	}
+
+	found, err := readCollectionOptions(ctx, db, "test")
+	if err != nil || found != nil {
+		t.Fatalf("Expected test not to match test2, got %+v, err %v", found, err)
+	}
+
+	found, err = readCollectionOptions(ctx, db, "test2")
+	if err != nil || found == nil {
+		t.Fatalf("Expected test2 to be found, got %+v, err %v", found, err)
+	}
+}
+
+func TestAccCollectionResourceMajorityVisible(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckReplicaSet(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_majority"
+	name = "visible"
+}
+`,
+				Check: func(_ *terraform.State) error {
+					db := testAccClient(t).Database("test_majority", options.Database().SetReadConcern(readconcern.Majority()))
+					names, err := db.ListCollectionNames(context.Background(), bson.D{{Key: "name", Value: "visible"}})
+					if err != nil {
+						return err
+					}
+					if len(names) != 1 {
+						return fmt.Errorf("expected the collection to be visible to a majority read, got %v", names)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestParseCollectionId(t *testing.T) {
+	id, err := parseCollectionId("test_db.test_collection")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if id.database != "test_db" || id.collection != "test_collection" {
+		t.Errorf("Expected test_db and test_collection, got %s and %s", id.database, id.collection)
+	}
+
+	id, err = parseCollectionId("test_db.system.views")
+	if err != nil || id.database != "test_db" || id.collection != "system.views" {
+		t.Errorf("Expected test_db and system.views, got %+v, %v", id, err)
+	}
+
+	for _, invalid := range []string{"test_db", "test_db.", ".test_collection", ""} {
+		if _, err := parseCollectionId(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestCollectionResourceModifyPlanNamespaceUpgrade(t *testing.T) {
+	ctx := context.Background()
+	r := &collectionResource{}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	// State written before the namespace attribute existed.
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	state.Set(ctx, &collectionResourceModel{
+		Namespace:  types.StringNull(),
+		Database:   "test_db",
+		Name:       "test",
+		IndexCount: types.Int64Value(1),
+		Timeouts:   testNullTimeouts(),
+		Id:         types.StringValue("test_db.test"),
+	})
+	configValues := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	configValues.Set(ctx, &collectionResourceModel{
+		Namespace:  types.StringNull(),
+		Database:   "test_db",
+		Name:       "test",
+		IndexCount: types.Int64Null(),
+		Timeouts:   testNullTimeouts(),
+		Id:         types.StringNull(),
+	})
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: configValues.Raw}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	plan.Set(ctx, &collectionResourceModel{
+		Namespace:  types.StringUnknown(),
+		Database:   "test_db",
+		Name:       "test",
+		IndexCount: types.Int64Value(1),
+		Timeouts:   testNullTimeouts(),
+		Id:         types.StringValue("test_db.test"),
+	})
+
+	resp := fwresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(resp.RequiresReplace) != 0 {
+		t.Errorf("Expected no replacement, got %v", resp.RequiresReplace)
+	}
+
+	var namespace types.String
+	resp.Plan.GetAttribute(ctx, path.Root("namespace"), &namespace)
+	if namespace.ValueString() != "test_db.test" {
+		t.Errorf("Expected namespace test_db.test, got %v", namespace)
+	}
+}
+
+func TestAccCollectionResourceNamespace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_namespace"
+	namespace = "test_namespace.conflict"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	namespace = "test_namespace"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid namespace"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	namespace = "test_namespace.test"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.test", "database", "test_namespace"),
+					resource.TestCheckResourceAttr("mongodb_collection.test", "name", "test"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_namespace"
+	name = "test"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.test", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	namespace = "test_namespace.renamed"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mongodb_collection.test", "name", "renamed"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "test" {
+	namespace = "test_namespace.with.dots"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_collection.test", "database", "test_namespace"),
+					resource.TestCheckResourceAttr("mongodb_collection.test", "name", "with.dots"),
+				),
+			},
+			{
+				ResourceName:      "mongodb_collection.test",
+				ImportState:       true,
+				ImportStateId:     "test_namespace.with.dots",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceDefaultCollation(t *testing.T) {
+	collationLocale := func(name string) (string, error) {
+		specs, err := testAccClient(t).Database("test_collation").ListCollectionSpecifications(context.Background(), bson.D{{Key: "name", Value: name}})
+		if err != nil {
+			return "", err
+		}
+		if len(specs) != 1 {
+			return "", fmt.Errorf("collection %s not found", name)
+		}
+		var found struct {
+			Collation struct {
+				Locale string `bson:"locale"`
+			} `bson:"collation"`
+		}
+		if err = bson.Unmarshal(specs[0].Options, &found); err != nil {
+			return "", err
+		}
+		return found.Collation.Locale, nil
+	}
+	checkLocale := func(name string, want string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			locale, err := collationLocale(name)
+			if err != nil {
+				return err
+			}
+			if locale != want {
+				return fmt.Errorf("expected collection %s to have the collation locale %q, got %q", name, want, locale)
+			}
+			return nil
+		}
+	}
+
+	collationConfig := `
+provider "mongodb" {
+  host = "localhost"
+  port = "27017"
+  username = "test"
+  password = "test"
+  default_collation_locale = "fr"
+}
+
+resource "mongodb_collection" "inherited" {
+	database = "test_collation"
+	name = "inherited"
+}
+
+resource "mongodb_collection" "overridden" {
+	database = "test_collation"
+	name = "overridden"
+	collation = {
+		locale = "de"
+	}
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: collationConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkLocale("inherited", "fr"),
+					checkLocale("overridden", "de"),
+				),
+			},
+			{
+				// The collection is recreated out of band with another collation, which is read back as drift.
+				PreConfig: func() {
+					db := testAccClient(t).Database("test_collation")
+					if err := db.Collection("overridden").Drop(context.Background()); err != nil {
+						t.Fatalf("Unable to drop collection: %v", err)
+					}
+					err := db.CreateCollection(context.Background(), "overridden", options.CreateCollection().SetCollation(&options.Collation{Locale: "es"}))
+					if err != nil {
+						t.Fatalf("Unable to create collection: %v", err)
+					}
+				},
+				Config: collationConfig,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_collection.inherited", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("mongodb_collection.overridden", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: checkLocale("overridden", "de"),
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceStrictDatabase(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  host = "localhost"
+  port = "27017"
+  username = "test"
+  password = "test"
+  strict_database = true
+}
+
+resource "mongodb_collection" "test" {
+	database = "test_strict_missing"
+	name = "test"
+}
+`,
+				ExpectError: regexp.MustCompile("Database not found"),
+			},
+		},
+	})
+}
+
+func TestAccCollectionResourceIndexCount(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_collection" "test" {
+	database = "test_index_count"
+	name = "counted"
+}
+
+resource "mongodb_index" "first" {
+	namespace = mongodb_collection.test.namespace
+	name = "first"
+	keys = [{ field = "a", type = "asc" }]
+}
+
+resource "mongodb_index" "second" {
+	namespace = mongodb_collection.test.namespace
+	name = "second"
+	keys = [{ field = "b", type = "desc" }]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("mongodb_collection.test", "index_count", "1"),
+			},
+			{
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("mongodb_collection.test", "index_count", "3"),
+			},
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &connectionStringDataSource{}
+	_ datasource.DataSourceWithConfigure = &connectionStringDataSource{}
+)
+
+// connectionStringDataSource is the data source implementation.
+type connectionStringDataSource struct {
+	client *mongodbClient
+}
+
+// connectionStringDataSourceModel maps the data source schema data.
+type connectionStringDataSourceModel struct {
+	Uri       types.String `tfsdk:"uri"`
+	StableAPI types.Bool   `tfsdk:"stable_api"`
+	Id        types.String `tfsdk:"id"`
+}
+
+// NewConnectionStringDataSource is a helper function to simplify the provider implementation.
+func NewConnectionStringDataSource() datasource.DataSource {
+	return &connectionStringDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *connectionStringDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB connection string data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB connection string data source")
+}
+
+// Metadata returns the data source type name.
+func (d *connectionStringDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_string"
+}
+
+// Schema defines the schema for the data source.
+func (d *connectionStringDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read the connection URI the provider connects with, to debug the provider configuration.",
+		Attributes: map[string]schema.Attribute{
+			"uri": schema.StringAttribute{
+				Description: "Connection URI equivalent to the options the provider connects with, whether they come from url " +
+					"or from provider attributes. Passwords and other secrets are redacted as ****. Certificates and the proxy " +
+					"have no URI equivalent and are left out.",
+				Computed: true,
+			},
+			"stable_api": schema.BoolAttribute{
+				Description: "Whether the provider uses the stable API v1, which has no URI equivalent.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *connectionStringDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state connectionStringDataSourceModel
+
+	state.Uri = types.StringValue(d.client.connectionString)
+	state.StableAPI = types.BoolValue(d.client.stableAPI)
+	state.Id = types.StringValue("to_be_ignored")
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &currentUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &currentUserDataSource{}
+)
+
+// currentUserDataSource is the data source implementation.
+type currentUserDataSource struct {
+	client *mongodbClient
+}
+
+// currentUserDataSourceModel maps the data source schema data.
+type currentUserDataSourceModel struct {
+	Database        *string      `tfsdk:"database"`
+	Collection      *string      `tfsdk:"collection"`
+	RequiredActions []string     `tfsdk:"required_actions"`
+	Authorized      bool         `tfsdk:"authorized"`
+	MissingActions  []string     `tfsdk:"missing_actions"`
+	Roles           []userRole   `tfsdk:"roles"`
+	Id              types.String `tfsdk:"id"`
+}
+
+type userRole struct {
+	Role string `tfsdk:"role" bson:"role"`
+	Db   string `tfsdk:"db" bson:"db"`
+}
+
+// connectionStatus maps the result of the connectionStatus command.
+type connectionStatus struct {
+	AuthInfo struct {
+		AuthenticatedUserRoles      []userRole  `bson:"authenticatedUserRoles"`
+		AuthenticatedUserPrivileges []privilege `bson:"authenticatedUserPrivileges"`
+	} `bson:"authInfo"`
+}
+
+type privilege struct {
+	Resource privilegeResource `bson:"resource"`
+	Actions  []string          `bson:"actions"`
+}
+
+// privilegeResource is the resource a privilege grants its actions on. An empty db or collection
+// means any database or collection.
+type privilegeResource struct {
+	Db          string `bson:"db"`
+	Collection  string `bson:"collection"`
+	Cluster     bool   `bson:"cluster"`
+	AnyResource bool   `bson:"anyResource"`
+}
+
+// clusterActions are the actions granted on the cluster resource rather than on a namespace.
+var clusterActions = map[string]bool{
+	"addShard":                       true,
+	"removeShard":                    true,
+	"listShards":                     true,
+	"listDatabases":                  true,
+	"listSessions":                   true,
+	"killAnySession":                 true,
+	"killop":                         true,
+	"inprog":                         true,
+	"dropConnections":                true,
+	"serverStatus":                   true,
+	"hostInfo":                       true,
+	"getParameter":                   true,
+	"setParameter":                   true,
+	"getCmdLineOpts":                 true,
+	"shutdown":                       true,
+	"fsync":                          true,
+	"replSetGetStatus":               true,
+	"replSetGetConfig":               true,
+	"replSetConfigure":               true,
+	"replSetStateChange":             true,
+	"getDefaultRWConcern":            true,
+	"setDefaultRWConcern":            true,
+	"setFeatureCompatibilityVersion": true,
+}
+
+// NewCurrentUserDataSource is a helper function to simplify the provider implementation.
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &currentUserDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *currentUserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB current user data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB current user data source")
+}
+
+// Metadata returns the data source type name.
+func (d *currentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+// Schema defines the schema for the data source.
+func (d *currentUserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read the roles and privileges of the user the provider is connected with.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Database the required actions must be granted on. Defaults to all databases.",
+				Optional:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "Collection of the database the required actions must be granted on. Defaults to the database itself.",
+				Optional:    true,
+			},
+			"required_actions": schema.ListAttribute{
+				Description: "Privilege actions the user must be granted, e.g. createCollection or dropDatabase. " +
+					"Cluster actions, e.g. listDatabases, must be granted on the cluster whatever the database.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"authorized": schema.BoolAttribute{
+				Description: "Whether the user is granted all the required actions.",
+				Computed:    true,
+			},
+			"missing_actions": schema.ListAttribute{
+				Description: "Required actions the user is not granted.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"roles": schema.ListNestedAttribute{
+				Description: "Roles of the user.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Description: "Name of the role.",
+							Computed:    true,
+						},
+						"db": schema.StringAttribute{
+							Description: "Database of the role.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *currentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state currentUserDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	tflog.Debug(ctx, "Reading current user")
+
+	var status connectionStatus
+	err := d.client.Database("admin").RunCommand(ctx, bson.D{
+		{Key: "connectionStatus", Value: 1},
+		{Key: "showPrivileges", Value: true},
+	}).Decode(&status)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read connection status",
+			"An unexpected error occurred when reading connection status. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Roles = status.AuthInfo.AuthenticatedUserRoles
+	if state.Roles == nil {
+		state.Roles = make([]userRole, 0)
+	}
+	state.MissingActions = missingActions(status.AuthInfo.AuthenticatedUserPrivileges, state.RequiredActions,
+		stringValue(state.Database), stringValue(state.Collection))
+	state.Authorized = len(state.MissingActions) == 0
+	state.Id = types.StringValue("to_be_ignored")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Read current user")
+}
+
+// Get the required actions which are not granted by any of the privileges on the namespace, or on the
+// cluster for cluster actions. An empty database or collection stands for all databases or the database itself.
+func missingActions(privileges []privilege, requiredActions []string, database string, collection string) []string {
+	granted := make(map[string]bool)
+	clusterGranted := make(map[string]bool)
+	for _, p := range privileges {
+		coversNamespace := p.Resource.covers(database, collection)
+		for _, action := range p.Actions {
+			if p.Resource.AnyResource || p.Resource.Cluster {
+				clusterGranted[action] = true
+			}
+			if coversNamespace {
+				granted[action] = true
+			}
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, action := range requiredActions {
+		if clusterActions[action] && !clusterGranted[action] || !clusterActions[action] && !granted[action] {
+			missing = append(missing, action)
+		}
+	}
+	return missing
+}
+
+// Check whether the resource covers the namespace of a database, or of a collection when set.
+// Resources on any collection don't cover system collections, which must be granted explicitly.
+func (r privilegeResource) covers(database string, collection string) bool {
+	if r.AnyResource {
+		return true
+	}
+	if r.Cluster || (r.Db != "" && r.Db != database) {
+		return false
+	}
+	if collection == "" {
+		return r.Collection == ""
+	}
+	return r.Collection == collection || (r.Collection == "" && !strings.HasPrefix(collection, "system."))
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAccCurrentUserDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_current_user" "test" {
+	required_actions = ["createCollection", "dropDatabase"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_current_user.test", "authorized", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_current_user.test", "missing_actions.#", "0"),
+					resource.TestCheckResourceAttrSet("data.mongodb_current_user.test", "roles.0.role"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCurrentUserDataSourceReadOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			client := testAccClient(t)
+			_ = client.Database("admin").RunCommand(context.Background(), bson.D{{Key: "dropUser", Value: "test_read_only"}}).Err()
+			err := client.Database("admin").RunCommand(context.Background(), bson.D{
+				{Key: "createUser", Value: "test_read_only"},
+				{Key: "pwd", Value: "test"},
+				{Key: "roles", Value: bson.A{bson.D{{Key: "role", Value: "read"}, {Key: "db", Value: "test_db"}}}},
+			}).Err()
+			if err != nil {
+				t.Fatalf("Unable to create read-only user: %v", err)
+			}
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			return testAccClient(t).Database("admin").RunCommand(context.Background(), bson.D{{Key: "dropUser", Value: "test_read_only"}}).Err()
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  host = "localhost"
+  port = "27017"
+  username = "test_read_only"
+  password = "test"
+}
+
+data "mongodb_current_user" "test" {
+	database = "test_db"
+	required_actions = ["find", "dropDatabase"]
+}
+
+data "mongodb_current_user" "other_database" {
+	database = "other_db"
+	required_actions = ["find"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_current_user.test", "authorized", "false"),
+					resource.TestCheckResourceAttr("data.mongodb_current_user.test", "missing_actions.#", "1"),
+					resource.TestCheckResourceAttr("data.mongodb_current_user.test", "missing_actions.0", "dropDatabase"),
+					resource.TestCheckResourceAttr("data.mongodb_current_user.test", "roles.0.role", "read"),
+					resource.TestCheckResourceAttr("data.mongodb_current_user.other_database", "authorized", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestMissingActions(t *testing.T) {
+	privileges := []privilege{
+		{Resource: privilegeResource{Db: "foo"}, Actions: []string{"find", "listCollections"}},
+		{Resource: privilegeResource{Db: "foo", Collection: "bar"}, Actions: []string{"insert"}},
+		{Resource: privilegeResource{}, Actions: []string{"collStats"}},
+		{Resource: privilegeResource{Cluster: true}, Actions: []string{"listDatabases"}},
+	}
+
+	cases := []struct {
+		name       string
+		database   string
+		collection string
+		required   []string
+		want       []string
+	}{
+		{"database", "foo", "", []string{"find", "listCollections", "collStats", "insert"}, []string{"insert"}},
+		{"collection", "foo", "bar", []string{"find", "insert", "dropCollection"}, []string{"dropCollection"}},
+		{"other database", "other", "", []string{"find", "collStats"}, []string{"find"}},
+		{"all databases", "", "", []string{"find", "collStats"}, []string{"find"}},
+		{"system collection", "foo", "system.views", []string{"find", "collStats"}, []string{"find", "collStats"}},
+		{"cluster action", "foo", "", []string{"listDatabases"}, []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			missing := missingActions(privileges, c.required, c.database, c.collection)
+			if !reflect.DeepEqual(missing, c.want) {
+				t.Fatalf("Expected %v, got %v", c.want, missing)
+			}
+		})
+	}
+
+	missing := missingActions([]privilege{{Resource: privilegeResource{Db: "foo"}, Actions: []string{"listDatabases"}}}, []string{"listDatabases"}, "foo", "")
+	if len(missing) != 1 {
+		t.Fatalf("Expected cluster actions granted on a database to be missing, got %v", missing)
+	}
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &databaseDataSource{}
+	_ datasource.DataSourceWithConfigure = &databaseDataSource{}
+)
+
+// databaseDataSource is the data source implementation.
+type databaseDataSource struct {
+	client *mongodbClient
+}
+
+// databaseDataSourceModel maps the data source schema data.
+type databaseDataSourceModel struct {
+	Name        string       `tfsdk:"name"`
+	Exists      bool         `tfsdk:"exists"`
+	Collections []string     `tfsdk:"collections"`
+	Id          types.String `tfsdk:"id"`
+}
+
+// NewDatabaseDataSource is a helper function to simplify the provider implementation.
+func NewDatabaseDataSource() datasource.DataSource {
+	return &databaseDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *databaseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB database data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB database data source")
+}
+
+// Metadata returns the data source type name.
+func (d *databaseDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database"
+}
+
+// Schema defines the schema for the data source.
+func (d *databaseDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read a database and the names of its collections, e.g. to create resources for each existing collection.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the database.",
+				Required:    true,
+			},
+			"exists": schema.BoolAttribute{
+				Description: "Whether the database exists.",
+				Computed:    true,
+			},
+			"collections": schema.ListAttribute{
+				Description: "Names of the collections and views of the database, sorted, system collections and the collections " +
+					"of the provider aside. Empty when the database does not exist.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *databaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state databaseDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Name
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading database %s", databaseName))
+
+	databases, err := d.client.ListDatabaseNames(ctx, bson.D{{Key: "name", Value: databaseName}})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list databases",
+			"An unexpected error occurred when listing databases. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Exists = len(databases) > 0
+	state.Collections = []string{}
+	if state.Exists {
+		state.Collections, err = listUserCollectionNames(ctx, d.client.readDatabase(databaseName))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to list collections",
+				"An unexpected error occurred when listing collections. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+		slices.Sort(state.Collections)
+	}
+	state.Id = types.StringValue(databaseName)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read database %s, %d collections", databaseName, len(state.Collections)))
+}
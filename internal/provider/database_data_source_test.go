@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatabaseDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "orders" {
+	database = "test_database_data_source"
+	name = "orders"
+	description = "Stored in the metadata collection, which is not listed"
+}
+
+resource "mongodb_collection" "customers" {
+	database = "test_database_data_source"
+	name = "customers"
+}
+`,
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "orders" {
+	database = "test_database_data_source"
+	name = "orders"
+	description = "Stored in the metadata collection, which is not listed"
+}
+
+resource "mongodb_collection" "customers" {
+	database = "test_database_data_source"
+	name = "customers"
+}
+
+data "mongodb_database" "test" {
+	name = "test_database_data_source"
+}
+
+data "mongodb_database" "missing" {
+	name = "test_database_data_source_missing"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_database.test", "exists", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_database.test", "collections.#", "2"),
+					resource.TestCheckResourceAttr("data.mongodb_database.test", "collections.0", "customers"),
+					resource.TestCheckResourceAttr("data.mongodb_database.test", "collections.1", "orders"),
+					resource.TestCheckResourceAttr("data.mongodb_database.missing", "exists", "false"),
+					resource.TestCheckResourceAttr("data.mongodb_database.missing", "collections.#", "0"),
+				),
+			},
+		},
+	})
+}
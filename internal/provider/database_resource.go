@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -12,7 +14,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
-	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -24,13 +27,14 @@ var (
 
 // databaseResource is the resource implementation.
 type databaseResource struct {
-	client *mongo.Client
+	client *mongodbClient
 }
 
 // databaseResourceModel maps the resource schema data.
 type databaseResourceModel struct {
-	Name string       `tfsdk:"name"`
-	Id   types.String `tfsdk:"id"`
+	Name     string         `tfsdk:"name"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	Id       types.String   `tfsdk:"id"`
 }
 
 // NewDatabaseResource is a helper function to simplify the provider implementation.
@@ -45,11 +49,11 @@ func (r *databaseResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*mongo.Client)
+	client, ok := req.ProviderData.(*mongodbClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -64,7 +68,7 @@ func (r *databaseResource) Metadata(_ context.Context, req resource.MetadataRequ
 }
 
 // Schema defines the schema for the resource.
-func (r *databaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *databaseResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Create databases in MongoDB.",
 		Attributes: map[string]schema.Attribute{
@@ -80,9 +84,23 @@ func (r *databaseResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				DeprecationMessage: "Just there for compatibility reasons",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Delete: true}),
+		},
 	}
 }
 
+// placeholderCollection is the collection created to create a database, which MongoDB creates implicitly
+// with its first collection.
+const placeholderCollection = "_terraform_created"
+
+// placeholderOptions are the options of the placeholder collection, capped to a few bytes since it never
+// holds documents.
+var placeholderOptions = options.CreateCollection().SetCapped(true).SetSizeInBytes(4096)
+
+// placeholderDropAttempts is the number of attempts to drop the placeholder collection of a failed creation.
+const placeholderDropAttempts = 3
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *databaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan databaseResourceModel
@@ -92,24 +110,65 @@ func (r *databaseResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
 	databaseName := plan.Name
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", "database "+databaseName, timeout)
 
 	tflog.Debug(ctx, fmt.Sprintf("Creating database %s", databaseName))
 
-	// In MongoDB, databases are created implicitly when you first store data in them.
-	// We'll create a dummy collection to ensure the database exists.
-	db := r.client.Database(databaseName)
-	err := db.CreateCollection(ctx, "_terraform_created")
+	// A database which already exists, e.g. created by an application, is just taken over.
+	databases, err := r.client.ListDatabaseNames(ctx, bson.D{{Key: "name", Value: databaseName}})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to create database",
-			"An unexpected error occurred when creating database. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+			"Unable to list databases",
+			"An unexpected error occurred when listing databases. "+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
+	if len(databases) > 0 {
+		tflog.Debug(ctx, fmt.Sprintf("Database %s already exists", databaseName))
+	} else {
+		// In MongoDB, databases are created implicitly when you first store data in them.
+		// We'll create a dummy collection to ensure the database exists.
+		db := r.client.ddlDatabase(databaseName)
+		err = db.CreateCollection(ctx, placeholderCollection, placeholderOptions)
+		if err != nil {
+			// The collection may have been created anyway, e.g. on a write concern error, leaving a database
+			// Terraform does not know about.
+			detail := "An unexpected error occurred when creating database. " +
+				reportFooter() +
+				"Error: " + err.Error()
+			dropErr := dropPlaceholder(ctx, db.Collection(placeholderCollection).Drop, placeholderDropAttempts, time.Second)
+			if dropErr != nil {
+				detail += fmt.Sprintf("\n\nThe %s collection could not be dropped afterwards, drop it manually: %s",
+					placeholderCollection, dropErr.Error())
+			}
+			resp.Diagnostics.AddError("Unable to create database", detail)
+			return
+		}
+	}
+
 	plan.Id = types.StringValue(databaseName)
 
 	// Set state to fully populated data
@@ -122,6 +181,25 @@ func (r *databaseResource) Create(ctx context.Context, req resource.CreateReques
 	tflog.Debug(ctx, fmt.Sprintf("Database %s created", databaseName))
 }
 
+// dropPlaceholder drops the placeholder collection, retrying on failure.
+func dropPlaceholder(ctx context.Context, drop func(context.Context) error, attempts int, delay time.Duration) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = drop(ctx)
+		if err == nil || attempt == attempts {
+			break
+		}
+		tflog.Warn(ctx, fmt.Sprintf("Unable to drop %s collection, attempt %d of %d: %v", placeholderCollection, attempt, attempts, err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	return err
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state databaseResourceModel
@@ -131,6 +209,9 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	ctx, release := r.client.withSession(ctx)
+	defer release()
+
 	databaseName := state.Name
 
 	tflog.Debug(ctx, fmt.Sprintf("Reading database %s", databaseName))
@@ -143,17 +224,16 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		resp.Diagnostics.AddError(
 			"Unable to list databases",
 			"An unexpected error occurred when listing databases. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
+	// A database dropped outside Terraform is planned to be created again.
 	if len(databases) == 0 {
-		resp.Diagnostics.AddError(
-			"Database not found",
-			fmt.Sprintf("Database %s does not exist", databaseName),
-		)
+		tflog.Warn(ctx, fmt.Sprintf("Database %s not found, removing it from the state", databaseName))
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -172,10 +252,15 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *databaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Updates not supported",
-		"Database updates are not supported. Changes to database configuration require recreation.",
-	)
+	// Changes to the name result in resource recreation, only the timeouts can change, which are just saved
+	// in the state.
+	var plan databaseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -187,16 +272,36 @@ func (r *databaseResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
 	databaseName := state.Name
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", "database "+databaseName, timeout)
 
 	tflog.Debug(ctx, fmt.Sprintf("Dropping database %s", databaseName))
 
-	err := r.client.Database(databaseName).Drop(ctx)
+	err = r.client.Database(databaseName).Drop(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to drop database",
 			"An unexpected error occurred when dropping database. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
@@ -1,9 +1,19 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestAccDatabaseResource(t *testing.T) {
@@ -23,3 +33,178 @@ resource "mongodb_database" "test_db_new" {
 		},
 	})
 }
+
+func TestAccDatabaseResourceExisting(t *testing.T) {
+	ctx := context.Background()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			db := testAccClient(t).Database("test_db_existing")
+			if err := db.Drop(ctx); err != nil {
+				t.Fatalf("Unable to drop database: %v", err)
+			}
+			if _, err := db.Collection("documents").InsertOne(ctx, bson.D{{Key: "a", Value: 1}}); err != nil {
+				t.Fatalf("Unable to seed database: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_database" "existing" {
+	name = "test_db_existing"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_database.existing", "name", "test_db_existing"),
+					func(_ *terraform.State) error {
+						names, err := testAccClient(t).Database("test_db_existing").ListCollectionNames(ctx, bson.D{{Key: "name", Value: placeholderCollection}})
+						if err != nil {
+							return err
+						}
+						if len(names) != 0 {
+							return fmt.Errorf("expected no %s collection in an existing database", placeholderCollection)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatabaseResourceDropped(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_database" "dropped" {
+	name = "test_db_dropped"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// A database dropped outside Terraform is created again rather than failing the plan.
+			{
+				PreConfig: func() {
+					if err := testAccClient(t).Database("test_db_dropped").Drop(context.Background()); err != nil {
+						t.Fatalf("Unable to drop database: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_database.dropped", plancheck.ResourceActionCreate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccDatabaseResourceTimeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_database" "timeouts" {
+	name = "test_db_timeouts"
+	timeouts {
+		create = "1ns"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Timeout exceeded`),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_database" "timeouts" {
+	name = "test_db_timeouts"
+	timeouts {
+		create = "5m"
+	}
+}
+`,
+				Check: resource.TestCheckResourceAttr("mongodb_database.timeouts", "timeouts.create", "5m"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_database" "timeouts" {
+	name = "test_db_timeouts"
+	timeouts {
+		create = "5m"
+		delete = "2m"
+	}
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_database.timeouts", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mongodb_database.timeouts", "timeouts.delete", "2m"),
+			},
+		},
+	})
+}
+
+func TestDropPlaceholder(t *testing.T) {
+	calls := 0
+	err := dropPlaceholder(context.Background(), func(context.Context) error {
+		calls++
+		return errors.New("transient failure")
+	}, 3, time.Millisecond)
+	if err == nil || calls != 3 {
+		t.Errorf("Expected the last error after 3 attempts, got %v after %d", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	err = dropPlaceholder(ctx, func(context.Context) error {
+		calls++
+		return errors.New("transient failure")
+	}, 3, time.Hour)
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("Expected to stop retrying once the context is done, got %v after %d", err, calls)
+	}
+}
+
+func TestAccDropPlaceholderTransientFailure(t *testing.T) {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+
+	ctx := context.Background()
+	db := testAccClient(t).Database("test_db_placeholder")
+	if err := db.CreateCollection(ctx, placeholderCollection, placeholderOptions); err != nil {
+		t.Fatalf("Unable to create placeholder: %v", err)
+	}
+
+	// The first drop fails as a network error would, the next ones drop the collection.
+	calls := 0
+	err := dropPlaceholder(ctx, func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return errors.New("connection reset by peer")
+		}
+		return db.Collection(placeholderCollection).Drop(ctx)
+	}, placeholderDropAttempts, 10*time.Millisecond)
+	if err != nil || calls != 2 {
+		t.Fatalf("Expected the placeholder to be dropped on the second attempt, got %v after %d", err, calls)
+	}
+
+	names, err := db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: placeholderCollection}})
+	if err != nil {
+		t.Fatalf("Unable to list collections: %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("Expected no %s collection left, got %v", placeholderCollection, names)
+	}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &databasesDataSource{}
+	_ datasource.DataSourceWithConfigure = &databasesDataSource{}
+)
+
+// databasesDataSource is the data source implementation.
+type databasesDataSource struct {
+	client *mongodbClient
+}
+
+// databasesDataSourceModel maps the data source schema data.
+type databasesDataSourceModel struct {
+	SizeGte   *int64         `tfsdk:"size_gte"`
+	SizeLte   *int64         `tfsdk:"size_lte"`
+	Empty     *bool          `tfsdk:"empty"`
+	Databases []databaseInfo `tfsdk:"databases"`
+	Id        types.String   `tfsdk:"id"`
+}
+
+type databaseInfo struct {
+	Name       string `tfsdk:"name"`
+	SizeOnDisk int64  `tfsdk:"size_on_disk"`
+	Empty      bool   `tfsdk:"empty"`
+}
+
+// NewDatabasesDataSource is a helper function to simplify the provider implementation.
+func NewDatabasesDataSource() datasource.DataSource {
+	return &databasesDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *databasesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB databases data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB databases data source")
+}
+
+// Metadata returns the data source type name.
+func (d *databasesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databases"
+}
+
+// Schema defines the schema for the data source.
+func (d *databasesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the databases of the server with their size, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"size_gte": schema.Int64Attribute{
+				Description: "Only list databases with a size on disk greater than or equal to this number of bytes.",
+				Optional:    true,
+			},
+			"size_lte": schema.Int64Attribute{
+				Description: "Only list databases with a size on disk less than or equal to this number of bytes.",
+				Optional:    true,
+			},
+			"empty": schema.BoolAttribute{
+				Description: "Only list databases without collections when true, or with collections when false. " +
+					"System collections, the placeholder collection of databases created by mongodb_database, " +
+					"and the collection storing collection descriptions are not counted.",
+				Optional: true,
+			},
+			"databases": schema.ListNestedAttribute{
+				Description: "Databases matching the filters, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the database.",
+							Computed:    true,
+						},
+						"size_on_disk": schema.Int64Attribute{
+							Description: "Size of the database files on disk, in bytes.",
+							Computed:    true,
+						},
+						"empty": schema.BoolAttribute{
+							Description: "Whether the database has no collections.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *databasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state databasesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	tflog.Debug(ctx, "Listing databases")
+
+	result, err := d.client.ListDatabases(ctx, bson.D{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list databases",
+			"An unexpected error occurred when listing databases. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	emptyDatabases := make(map[string]bool)
+	for _, database := range result.Databases {
+		emptyDatabases[database.Name], err = isEmptyDatabase(ctx, d.client.Database(database.Name))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to list collections",
+				"An unexpected error occurred when listing collections. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	state.Databases = state.filterDatabases(result.Databases, emptyDatabases)
+	state.Id = types.StringValue("to_be_ignored")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Listed %d databases", len(state.Databases)))
+}
+
+// filterDatabases keeps the databases matching the size and empty filters of the model.
+func (m *databasesDataSourceModel) filterDatabases(databases []mongo.DatabaseSpecification, emptyDatabases map[string]bool) []databaseInfo {
+	filtered := make([]databaseInfo, 0, len(databases))
+	for _, database := range databases {
+		if m.SizeGte != nil && database.SizeOnDisk < *m.SizeGte {
+			continue
+		}
+		if m.SizeLte != nil && database.SizeOnDisk > *m.SizeLte {
+			continue
+		}
+		if m.Empty != nil && emptyDatabases[database.Name] != *m.Empty {
+			continue
+		}
+		filtered = append(filtered, databaseInfo{
+			Name:       database.Name,
+			SizeOnDisk: database.SizeOnDisk,
+			Empty:      emptyDatabases[database.Name],
+		})
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Name < filtered[j].Name })
+	return filtered
+}
+
+// Check whether the database has no collections, system collections and the collections of the provider aside.
+func isEmptyDatabase(ctx context.Context, db *mongo.Database) (bool, error) {
+	names, err := listUserCollectionNames(ctx, db)
+	if err != nil {
+		return false, err
+	}
+	return len(names) == 0, nil
+}
+
+// listUserCollectionNames lists the names of the collections of the database, system collections and the
+// collections of the provider aside.
+func listUserCollectionNames(ctx context.Context, db *mongo.Database) ([]string, error) {
+	return db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: bson.D{
+		{Key: "$nin", Value: bson.A{placeholderCollection, metadataCollection}},
+		{Key: "$not", Value: primitive.Regex{Pattern: "^system\\."}},
+	}}}, options.ListCollections().SetNameOnly(true))
+}
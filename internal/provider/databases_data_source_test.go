@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestFilterDatabases(t *testing.T) {
+	databases := []mongo.DatabaseSpecification{
+		{Name: "small", SizeOnDisk: 100},
+		{Name: "large", SizeOnDisk: 10000},
+		{Name: "medium", SizeOnDisk: 1000},
+		{Name: "empty", SizeOnDisk: 0},
+	}
+	emptyDatabases := map[string]bool{"empty": true}
+	size := func(n int64) *int64 { return &n }
+	empty := true
+	notEmpty := false
+
+	cases := []struct {
+		model databasesDataSourceModel
+		want  []string
+	}{
+		{databasesDataSourceModel{}, []string{"empty", "large", "medium", "small"}},
+		{databasesDataSourceModel{SizeGte: size(1000)}, []string{"large", "medium"}},
+		{databasesDataSourceModel{SizeLte: size(1000)}, []string{"empty", "medium", "small"}},
+		{databasesDataSourceModel{SizeGte: size(100), SizeLte: size(1000)}, []string{"medium", "small"}},
+		{databasesDataSourceModel{Empty: &empty}, []string{"empty"}},
+		{databasesDataSourceModel{Empty: &notEmpty}, []string{"large", "medium", "small"}},
+	}
+	for _, c := range cases {
+		names := make([]string, 0)
+		for _, database := range c.model.filterDatabases(databases, emptyDatabases) {
+			names = append(names, database.Name)
+		}
+		if !reflect.DeepEqual(names, c.want) {
+			t.Errorf("Expected %v, got %v", c.want, names)
+		}
+	}
+}
+
+func TestAccDatabasesDataSource(t *testing.T) {
+	ctx := context.Background()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			client := testAccClient(t)
+			// A large database, the smallest database size on disk is a few KB.
+			documents := make([]interface{}, 0, 1000)
+			for i := 0; i < 1000; i++ {
+				documents = append(documents, bson.D{{Key: "payload", Value: fmt.Sprintf("%01000d", i)}})
+			}
+			if _, err := client.Database("test_databases_large").Collection("documents").InsertMany(ctx, documents); err != nil {
+				t.Fatalf("Unable to seed database: %v", err)
+			}
+			if _, err := client.Database("test_databases_small").Collection("documents").InsertOne(ctx, bson.D{{Key: "a", Value: 1}}); err != nil {
+				t.Fatalf("Unable to seed database: %v", err)
+			}
+			// The database size is only updated on checkpoints.
+			if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "fsync", Value: 1}}).Err(); err != nil {
+				t.Fatalf("Unable to flush data to disk: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_databases" "large" {
+	size_gte = 500000
+}
+
+data "mongodb_databases" "small" {
+	size_lte = 499999
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckDatabaseListed("data.mongodb_databases.large", "test_databases_large", true),
+					testCheckDatabaseListed("data.mongodb_databases.large", "test_databases_small", false),
+					testCheckDatabaseListed("data.mongodb_databases.small", "test_databases_small", true),
+					testCheckDatabaseListed("data.mongodb_databases.small", "test_databases_large", false),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_database" "empty" {
+	name = "test_databases_empty"
+}
+
+data "mongodb_databases" "empty" {
+	empty = true
+
+	depends_on = [mongodb_database.empty]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckDatabaseListed("data.mongodb_databases.empty", "test_databases_empty", true),
+					testCheckDatabaseListed("data.mongodb_databases.empty", "test_databases_small", false),
+				),
+			},
+		},
+	})
+}
+
+// testCheckDatabaseListed checks whether the database is listed by the databases data source.
+func testCheckDatabaseListed(dataSource string, database string, listed bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		attributes := s.RootModule().Resources[dataSource].Primary.Attributes
+		found := false
+		for key, value := range attributes {
+			if value == database && strings.HasPrefix(key, "databases.") && strings.HasSuffix(key, ".name") {
+				found = true
+			}
+		}
+		if found != listed {
+			return fmt.Errorf("expected database %s listed by %s to be %t", database, dataSource, listed)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parseDNSResolver checks the address of a DNS nameserver, an IP address with an optional port, and returns it
+// as ip:port, the port defaulting to 53.
+func parseDNSResolver(address string) (string, error) {
+	host, port := address, "53"
+	if net.ParseIP(strings.Trim(address, "[]")) == nil {
+		var err error
+		host, port, err = net.SplitHostPort(address)
+		if err != nil {
+			return "", fmt.Errorf("expected an IP address with an optional port, e.g. 10.0.0.2 or 10.0.0.2:5353, got %q", address)
+		}
+		if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+			return "", fmt.Errorf("invalid port %q, expected a number between 1 and 65535", port)
+		}
+	}
+	host = strings.Trim(host, "[]")
+	if net.ParseIP(host) == nil {
+		return "", fmt.Errorf("nameserver %q must be an IP address, as it cannot be resolved itself", host)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// newDNSResolver creates a resolver sending all its queries to the nameserver at address, as returned by
+// parseDNSResolver.
+func newDNSResolver(address string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// resolveWithDNSResolver resolves the mongodb+srv connection string with the nameserver at address.
+func resolveWithDNSResolver(ctx context.Context, address string, uri string) (string, error) {
+	nameserver, err := parseDNSResolver(address)
+	if err != nil {
+		return "", err
+	}
+	return resolveSRVConnectionString(ctx, newDNSResolver(nameserver), uri)
+}
+
+// resolveSRVConnectionString resolves the hosts and default options of a mongodb+srv connection string with the
+// resolver, into the equivalent mongodb:// connection string. As done by the driver, TLS is enabled unless the
+// connection string disables it, and its options take precedence over the ones of the TXT record.
+func resolveSRVConnectionString(ctx context.Context, resolver srvResolver, uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse url: %w", err)
+	}
+	query := parsed.Query()
+	for _, option := range []string{"srvServiceName", "srvMaxHosts"} {
+		if query.Has(option) {
+			return "", fmt.Errorf("the %s option is not supported with dns_resolver", option)
+		}
+	}
+
+	hosts, txtOptions, err := resolveSRVHosts(ctx, resolver, parsed.Hostname())
+	if err != nil {
+		return "", err
+	}
+
+	defaults, err := url.ParseQuery(txtOptions)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse TXT record options %q: %w", txtOptions, err)
+	}
+	for option, values := range defaults {
+		if !query.Has(option) {
+			query[option] = values
+		}
+	}
+	if !query.Has("tls") && !query.Has("ssl") {
+		query.Set("tls", "true")
+	}
+
+	resolved := "mongodb://"
+	if parsed.User != nil {
+		resolved += parsed.User.String() + "@"
+	}
+	resolved += strings.Join(hosts, ",") + "/" + strings.TrimPrefix(parsed.EscapedPath(), "/")
+	if len(query) > 0 {
+		resolved += "?" + query.Encode()
+	}
+	return resolved, nil
+}
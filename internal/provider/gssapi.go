@@ -0,0 +1,7 @@
+//go:build gssapi && (windows || linux || darwin)
+
+package provider
+
+// gssapiSupported is whether the provider is built with GSSAPI (Kerberos) support, which requires the gssapi build
+// tag and cgo on linux and darwin.
+const gssapiSupported = true
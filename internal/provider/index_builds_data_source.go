@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &indexBuildsDataSource{}
+	_ datasource.DataSourceWithConfigure = &indexBuildsDataSource{}
+)
+
+// indexBuildsDataSource is the data source implementation.
+type indexBuildsDataSource struct {
+	client *mongodbClient
+}
+
+// indexBuildsDataSourceModel maps the data source schema data.
+type indexBuildsDataSourceModel struct {
+	Database   string       `tfsdk:"database"`
+	Collection string       `tfsdk:"collection"`
+	Builds     []indexBuild `tfsdk:"builds"`
+	Id         types.String `tfsdk:"id"`
+}
+
+type indexBuild struct {
+	Indexes        []string     `tfsdk:"indexes"`
+	Phase          types.String `tfsdk:"phase"`
+	ProgressDone   types.Int64  `tfsdk:"progress_done"`
+	ProgressTotal  types.Int64  `tfsdk:"progress_total"`
+	SecondsRunning int64        `tfsdk:"seconds_running"`
+}
+
+// indexBuildOperation maps the index build operations returned by currentOp.
+type indexBuildOperation struct {
+	Command struct {
+		Indexes []struct {
+			Name string `bson:"name"`
+		} `bson:"indexes"`
+	} `bson:"command"`
+	Msg      string `bson:"msg"`
+	Progress *struct {
+		Done  int64 `bson:"done"`
+		Total int64 `bson:"total"`
+	} `bson:"progress"`
+	SecsRunning int64 `bson:"secs_running"`
+}
+
+// NewIndexBuildsDataSource is a helper function to simplify the provider implementation.
+func NewIndexBuildsDataSource() datasource.DataSource {
+	return &indexBuildsDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *indexBuildsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB index builds data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB index builds data source")
+}
+
+// Metadata returns the data source type name.
+func (d *indexBuildsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index_builds"
+}
+
+// Schema defines the schema for the data source.
+func (d *indexBuildsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the index builds in progress on a collection, e.g. to wait for them before dependent operations. " +
+			"Requires the inprog action on the cluster.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database of the collection.",
+				Required:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "Name of the collection.",
+				Required:    true,
+			},
+			"builds": schema.ListNestedAttribute{
+				Description: "Index builds in progress, empty when none.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"indexes": schema.ListAttribute{
+							Description: "Names of the indexes built together.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"phase": schema.StringAttribute{
+							Description: "Current phase of the build, e.g. Index Build: scanning collection. Null before the build starts scanning.",
+							Computed:    true,
+						},
+						"progress_done": schema.Int64Attribute{
+							Description: "Number of documents or keys processed in the current phase, null when the phase reports no progress.",
+							Computed:    true,
+						},
+						"progress_total": schema.Int64Attribute{
+							Description: "Number of documents or keys to process in the current phase, null when the phase reports no progress.",
+							Computed:    true,
+						},
+						"seconds_running": schema.Int64Attribute{
+							Description: "Seconds since the build started.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *indexBuildsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state indexBuildsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database
+	collectionName := state.Collection
+
+	tflog.Debug(ctx, fmt.Sprintf("Listing index builds of %s.%s", databaseName, collectionName))
+
+	// Both the createIndexes command and the thread building the indexes are reported, on the collection namespace.
+	var result struct {
+		InProg []indexBuildOperation `bson:"inprog"`
+	}
+	err := d.client.Database("admin").RunCommand(ctx, bson.D{
+		{Key: "currentOp", Value: true},
+		{Key: "ns", Value: fmt.Sprintf("%s.%s", databaseName, collectionName)},
+		{Key: "command.createIndexes", Value: collectionName},
+	}).Decode(&result)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list current operations",
+			"An unexpected error occurred when listing current operations. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Builds = toIndexBuilds(result.InProg)
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Listed %d index builds of %s.%s", len(state.Builds), databaseName, collectionName))
+}
+
+// toIndexBuilds converts the index build operations into one build per set of indexes, preferring the operation
+// reporting the build progress over the createIndexes command waiting for it.
+func toIndexBuilds(operations []indexBuildOperation) []indexBuild {
+	builds := []indexBuild{}
+	positions := map[string]int{}
+	for _, operation := range operations {
+		build := indexBuild{
+			Indexes:        make([]string, 0, len(operation.Command.Indexes)),
+			Phase:          types.StringNull(),
+			ProgressDone:   types.Int64Null(),
+			ProgressTotal:  types.Int64Null(),
+			SecondsRunning: operation.SecsRunning,
+		}
+		for _, index := range operation.Command.Indexes {
+			build.Indexes = append(build.Indexes, index.Name)
+		}
+		slices.Sort(build.Indexes)
+		if operation.Msg != "" {
+			build.Phase = types.StringValue(operation.Msg)
+		}
+		if operation.Progress != nil {
+			build.ProgressDone = types.Int64Value(operation.Progress.Done)
+			build.ProgressTotal = types.Int64Value(operation.Progress.Total)
+		}
+
+		key := strings.Join(build.Indexes, "\x00")
+		position, found := positions[key]
+		switch {
+		case !found:
+			positions[key] = len(builds)
+			builds = append(builds, build)
+		case builds[position].Phase.IsNull() && !build.Phase.IsNull():
+			builds[position] = build
+		}
+	}
+	return builds
+}
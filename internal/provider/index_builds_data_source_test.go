@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestToIndexBuilds(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{{Key: "inprog", Value: bson.A{
+		// The createIndexes command waiting for the build.
+		bson.D{
+			{Key: "command", Value: bson.D{
+				{Key: "createIndexes", Value: "orders"},
+				{Key: "indexes", Value: bson.A{bson.D{{Key: "name", Value: "b_1"}}, bson.D{{Key: "name", Value: "a_1"}}}},
+			}},
+			{Key: "secs_running", Value: int64(12)},
+		},
+		// The thread building the indexes.
+		bson.D{
+			{Key: "command", Value: bson.D{
+				{Key: "createIndexes", Value: "orders"},
+				{Key: "indexes", Value: bson.A{bson.D{{Key: "name", Value: "a_1"}}, bson.D{{Key: "name", Value: "b_1"}}}},
+			}},
+			{Key: "msg", Value: "Index Build: scanning collection Index Build: scanning collection: 250/1000 25%"},
+			{Key: "progress", Value: bson.D{{Key: "done", Value: int64(250)}, {Key: "total", Value: int64(1000)}}},
+			{Key: "secs_running", Value: int64(11)},
+		},
+		// Another build which did not start scanning yet.
+		bson.D{
+			{Key: "command", Value: bson.D{
+				{Key: "createIndexes", Value: "orders"},
+				{Key: "indexes", Value: bson.A{bson.D{{Key: "name", Value: "c_1"}}}},
+			}},
+			{Key: "secs_running", Value: int64(0)},
+		},
+	}}})
+
+	var result struct {
+		InProg []indexBuildOperation `bson:"inprog"`
+	}
+	if err := bson.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("Unable to parse operations: %v", err)
+	}
+
+	want := []indexBuild{
+		{
+			Indexes:        []string{"a_1", "b_1"},
+			Phase:          types.StringValue("Index Build: scanning collection Index Build: scanning collection: 250/1000 25%"),
+			ProgressDone:   types.Int64Value(250),
+			ProgressTotal:  types.Int64Value(1000),
+			SecondsRunning: 11,
+		},
+		{
+			Indexes:        []string{"c_1"},
+			Phase:          types.StringNull(),
+			ProgressDone:   types.Int64Null(),
+			ProgressTotal:  types.Int64Null(),
+			SecondsRunning: 0,
+		},
+	}
+	if builds := toIndexBuilds(result.InProg); !reflect.DeepEqual(builds, want) {
+		t.Errorf("Expected builds %+v, got %+v", want, builds)
+	}
+
+	if builds := toIndexBuilds(nil); builds == nil || len(builds) != 0 {
+		t.Errorf("Expected an empty list without builds, got %v", builds)
+	}
+}
+
+func TestAccIndexBuildsDataSource(t *testing.T) {
+	ctx := context.Background()
+	collection := func() *mongo.Collection {
+		return testAccClient(t).Database("test_index_builds").Collection("orders")
+	}
+	failPoint := func(mode string) error {
+		return testAccClient(t).Database("admin").RunCommand(ctx, bson.D{
+			{Key: "configureFailPoint", Value: "hangAfterStartingIndexBuild"},
+			{Key: "mode", Value: mode},
+		}).Err()
+	}
+	built := make(chan error, 1)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			// The build is held in progress by a fail point, which requires the server to enable test commands.
+			if err := failPoint("off"); err != nil {
+				t.Skipf("Fail points not available, start the server with enableTestCommands: %v", err)
+			}
+			_ = testAccClient(t).Database("test_index_builds").Drop(ctx)
+			if _, err := collection().InsertOne(ctx, bson.D{{Key: "total", Value: 1}}); err != nil {
+				t.Fatalf("Unable to insert document: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_index_builds" "test" {
+	database = "test_index_builds"
+	collection = "orders"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.mongodb_index_builds.test", "builds.#", "0"),
+			},
+			{
+				PreConfig: func() {
+					if err := failPoint("alwaysOn"); err != nil {
+						t.Fatalf("Unable to enable fail point: %v", err)
+					}
+					go func() {
+						_, err := collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+							Keys:    bson.D{{Key: "total", Value: 1}},
+							Options: options.Index().SetName("total_1"),
+						})
+						built <- err
+					}()
+				},
+				Config: providerConfig + `
+data "mongodb_index_builds" "test" {
+	database = "test_index_builds"
+	collection = "orders"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_index_builds.test", "builds.#", "1"),
+					resource.TestCheckResourceAttr("data.mongodb_index_builds.test", "builds.0.indexes.#", "1"),
+					resource.TestCheckResourceAttr("data.mongodb_index_builds.test", "builds.0.indexes.0", "total_1"),
+					resource.TestCheckResourceAttrSet("data.mongodb_index_builds.test", "builds.0.seconds_running"),
+					func(_ *terraform.State) error {
+						if err := failPoint("off"); err != nil {
+							return err
+						}
+						return <-built
+					},
+				),
+			},
+		},
+	})
+}
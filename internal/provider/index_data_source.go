@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &indexDataSource{}
+	_ datasource.DataSourceWithConfigure = &indexDataSource{}
+)
+
+// indexDataSource is the data source implementation.
+type indexDataSource struct {
+	client *mongodbClient
+}
+
+// indexDataSourceModel maps the data source schema data.
+type indexDataSourceModel struct {
+	Database                string       `tfsdk:"database"`
+	Collection              string       `tfsdk:"collection"`
+	Name                    string       `tfsdk:"name"`
+	Exists                  bool         `tfsdk:"exists"`
+	Keys                    []indexKey   `tfsdk:"keys"`
+	Unique                  bool         `tfsdk:"unique"`
+	Sparse                  bool         `tfsdk:"sparse"`
+	ExpireAfterSeconds      *int32       `tfsdk:"expire_after_seconds"`
+	PartialFilterExpression *string      `tfsdk:"partial_filter_expression"`
+	Collation               *collation   `tfsdk:"collation"`
+	Id                      types.String `tfsdk:"id"`
+}
+
+// NewIndexDataSource is a helper function to simplify the provider implementation.
+func NewIndexDataSource() datasource.DataSource {
+	return &indexDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *indexDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB index data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB index data source")
+}
+
+// Metadata returns the data source type name.
+func (d *indexDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index"
+}
+
+// Schema defines the schema for the data source.
+func (d *indexDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read an index by name, e.g. to check that it exists before importing it.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database of the collection.",
+				Required:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "Name of the collection.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the index.",
+				Required:    true,
+			},
+			"exists": schema.BoolAttribute{
+				Description: "Whether the index exists. The other attributes are empty when it does not.",
+				Computed:    true,
+			},
+			"keys": schema.ListNestedAttribute{
+				Description: "Fields composing the index, in order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Description: "Name of the field.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the index on the field, e.g. asc, desc or text.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"unique": schema.BoolAttribute{
+				Description: "Is it a unique index.",
+				Computed:    true,
+			},
+			"sparse": schema.BoolAttribute{
+				Description: "Is it a sparse index.",
+				Computed:    true,
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Description: "Documents ttl in seconds for ttl indexes.",
+				Computed:    true,
+			},
+			"partial_filter_expression": schema.StringAttribute{
+				Description: "Filter of the documents indexed by partial indexes, in canonical Extended JSON.",
+				Computed:    true,
+			},
+			"collation": collationDataSourceAttribute("Index collation, as returned by the server."),
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *indexDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state indexDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database
+	collectionName := state.Collection
+	indexName := state.Name
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading index %s.%s.%s", databaseName, collectionName, indexName))
+
+	// The indexes of a collection which does not exist cannot be listed, neither does the index exist.
+	document, err := findIndex(ctx, d.client.readDatabase(databaseName).Collection(collectionName), indexName)
+	if isNamespaceNotFound(err) {
+		document, err = nil, nil
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list indexes",
+			"An unexpected error occurred when listing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	err = state.setIndex(document)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse index "+indexName,
+			"An unexpected error occurred when parsing index. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", databaseName, collectionName, indexName))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read index %s.%s.%s, exists: %t", databaseName, collectionName, indexName, state.Exists))
+}
+
+// setIndex sets the attributes from the index document, nil when the index does not exist.
+func (m *indexDataSourceModel) setIndex(document *indexDocument) error {
+	m.Exists = document != nil
+	m.Keys = []indexKey{}
+	m.Unique, m.Sparse = false, false
+	m.ExpireAfterSeconds, m.PartialFilterExpression, m.Collation = nil, nil, nil
+	if document == nil {
+		return nil
+	}
+
+	info, err := document.toIndexInfo()
+	if err != nil {
+		return err
+	}
+	m.PartialFilterExpression, err = readPartialFilter(nil, document.PartialFilterExpression)
+	if err != nil {
+		return err
+	}
+	m.Keys = info.Keys
+	m.Unique = info.Unique
+	m.Sparse = info.Sparse
+	m.ExpireAfterSeconds = info.ExpireAfterSeconds
+	m.Collation = info.Collation
+	return nil
+}
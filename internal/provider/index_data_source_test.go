@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestIndexDataSourceModelSetIndex(t *testing.T) {
+	key, _ := bson.Marshal(bson.D{{Key: "email", Value: 1}, {Key: "created_at", Value: -1}})
+	filter, _ := bson.Marshal(bson.D{{Key: "status", Value: "active"}})
+
+	var model indexDataSourceModel
+	err := model.setIndex(&indexDocument{Name: "email", Key: key, Unique: true, PartialFilterExpression: filter})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !model.Exists || !model.Unique || model.Sparse || len(model.Keys) != 2 || model.Keys[1] != (indexKey{Field: "created_at", Type: "desc"}) {
+		t.Errorf("Unexpected index %+v", model)
+	}
+	if model.PartialFilterExpression == nil || *model.PartialFilterExpression != `{"status":"active"}` {
+		t.Errorf("Expected the partial filter in Extended JSON, got %v", model.PartialFilterExpression)
+	}
+
+	// A missing index resets the attributes.
+	if err = model.setIndex(nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if model.Exists || model.Unique || len(model.Keys) != 0 || model.PartialFilterExpression != nil {
+		t.Errorf("Expected an empty index, got %+v", model)
+	}
+}
+
+func TestAccIndexDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			collection := testAccClient(t).Database("test_index_data_source").Collection("users")
+			_, err := collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+				Keys:    bson.D{{Key: "email", Value: 1}},
+				Options: options.Index().SetName("email_unique").SetUnique(true).SetCollation(&options.Collation{Locale: "en", Strength: 2}),
+			})
+			if err != nil {
+				t.Fatalf("Unable to create index: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_index" "test" {
+	database = "test_index_data_source"
+	collection = "users"
+	name = "email_unique"
+}
+
+data "mongodb_index" "missing" {
+	database = "test_index_data_source"
+	collection = "users"
+	name = "missing"
+}
+
+data "mongodb_index" "missing_collection" {
+	database = "test_index_data_source"
+	collection = "missing"
+	name = "email_unique"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_index.test", "exists", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_index.test", "unique", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_index.test", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.mongodb_index.test", "keys.0.field", "email"),
+					resource.TestCheckResourceAttr("data.mongodb_index.test", "collation.strength", "2"),
+					resource.TestCheckNoResourceAttr("data.mongodb_index.test", "partial_filter_expression"),
+					resource.TestCheckResourceAttr("data.mongodb_index.missing", "exists", "false"),
+					resource.TestCheckResourceAttr("data.mongodb_index.missing", "keys.#", "0"),
+					resource.TestCheckResourceAttr("data.mongodb_index.missing_collection", "exists", "false"),
+				),
+			},
+		},
+	})
+}
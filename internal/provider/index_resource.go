@@ -1,15 +1,24 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math"
+	"reflect"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
@@ -18,37 +27,50 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &indexResource{}
-	_ resource.ResourceWithConfigure   = &indexResource{}
-	_ resource.ResourceWithImportState = &indexResource{}
+	_ resource.Resource                   = &indexResource{}
+	_ resource.ResourceWithConfigure      = &indexResource{}
+	_ resource.ResourceWithImportState    = &indexResource{}
+	_ resource.ResourceWithModifyPlan     = &indexResource{}
+	_ resource.ResourceWithValidateConfig = &indexResource{}
 )
 
 // indexResource is the resource implementation.
 type indexResource struct {
-	client *mongo.Client
+	client *mongodbClient
 }
 
 // indexResourceModel maps the resource schema data.
 type indexResourceModel struct {
-	Database           string            `tfsdk:"database"`
-	Collection         string            `tfsdk:"collection"`
-	Name               string            `tfsdk:"name"`
-	Keys               []indexKey        `tfsdk:"keys"`
-	Sparse             *bool             `tfsdk:"sparse"`
-	ExpireAfterSeconds *int32            `tfsdk:"expire_after_seconds"`
-	Unique             *bool             `tfsdk:"unique"`
-	WildcardProjection *map[string]int32 `tfsdk:"wildcard_projection"`
-	Collation          *collation        `tfsdk:"collation"`
-	Background         *bool             `tfsdk:"background"`
+	Namespace               types.String      `tfsdk:"namespace"`
+	Database                string            `tfsdk:"database"`
+	Collection              string            `tfsdk:"collection"`
+	Name                    string            `tfsdk:"name"`
+	Keys                    []indexKey        `tfsdk:"keys"`
+	Sparse                  *bool             `tfsdk:"sparse"`
+	ExpireAfterSeconds      *int32            `tfsdk:"expire_after_seconds"`
+	Unique                  *bool             `tfsdk:"unique"`
+	PartialFilterExpression *string           `tfsdk:"partial_filter_expression"`
+	WildcardProjection      *map[string]int32 `tfsdk:"wildcard_projection"`
+	Collation               *collation        `tfsdk:"collation"`
+	Background              *bool             `tfsdk:"background"`
+	WTimeoutSeconds         *int64            `tfsdk:"w_timeout_seconds"`
+	SphereIndexVersion      types.Int64       `tfsdk:"sphere_index_version"`
+	Bits                    *int32            `tfsdk:"bits"`
+	Min                     *float64          `tfsdk:"min"`
+	Max                     *float64          `tfsdk:"max"`
+	TextIndexVersion        types.Int64       `tfsdk:"text_index_version"`
+	Timeouts                timeouts.Value    `tfsdk:"timeouts"`
 
 	// see https://developer.hashicorp.com/terraform/plugin/framework/acctests#implement-id-attribute
 	Id types.String `tfsdk:"id"`
@@ -83,11 +105,11 @@ func (d *indexResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*mongo.Client)
+	client, ok := req.ProviderData.(*mongodbClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -103,21 +125,37 @@ func (r *indexResource) Metadata(_ context.Context, req resource.MetadataRequest
 }
 
 // Schema defines the schema for the resource.
-func (r *indexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *indexResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Create indexes in MongoDB.",
 		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Description: "Namespace of the collection where to create the index, as <database>.<collection>. Alternative to database and collection.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("database")),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("collection")),
+				},
+			},
 			"database": schema.StringAttribute{
 				Description: "Name of the database where to create the index.",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"collection": schema.StringAttribute{
 				Description: "Name of the collection where to create the index.",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -143,9 +181,6 @@ func (r *indexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						"type": schema.StringAttribute{
 							Description: "The type of index for this field.",
 							Required:    true,
-							Validators: []validator.String{
-								stringvalidator.NoneOf("text"),
-							},
 						},
 					},
 				},
@@ -154,17 +189,27 @@ func (r *indexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"sparse": schema.BoolAttribute{
-				Description: "Is it a sparse index.",
+				Description: "Is it a sparse index, which skips the documents missing the indexed fields. Conflicts with partial_filter_expression.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
 			"expire_after_seconds": schema.Int64Attribute{
-				Description: "Documents ttl in seconds for ttl indexes.",
-				Optional:    true,
+				Description: "Documents ttl in seconds for ttl indexes. 0 expires documents at the date of their indexed field, " +
+					"unlike an unset value which makes a regular index. Can be changed without recreating the index.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, math.MaxInt32),
+				},
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+					int64planmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+						},
+						"An index cannot be converted to or from a ttl index.",
+						"An index cannot be converted to or from a ttl index.",
+					),
 				},
 			},
 			"unique": schema.BoolAttribute{
@@ -174,13 +219,26 @@ func (r *indexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
+			"partial_filter_expression": schema.StringAttribute{
+				Description: "Filter of the documents to index, as a document in MongoDB Extended JSON, e.g. `{\"status\": \"active\"}`. " +
+					"Only documents matching it are indexed, and checked for uniqueness with unique. Partial indexes " +
+					"supersede sparse indexes, both cannot be combined.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"wildcard_projection": schema.MapAttribute{
-				Description: "Projection for wirldcard indexes.",
+				Description: "Projection of wildcard indexes, whose single field is $**, with the fields to include as 1 or to " +
+					"exclude as 0, e.g. `{ \"attributes\" = 1 }`. Inclusions and exclusions cannot be combined, _id aside.",
 				ElementType: types.Int64Type,
 				Optional:    true,
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.Map{
+					mapvalidator.ValueInt64sAre(int64validator.OneOf(0, 1)),
+				},
 			},
 			"background": schema.BoolAttribute{
 				Description: "Create the index in the background.",
@@ -189,76 +247,55 @@ func (r *indexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
-			"collation": schema.SingleNestedAttribute{
-				Description: "Index collation.",
+			"w_timeout_seconds": schema.Int64Attribute{
+				Description: "Wait at most this many seconds for a majority of the replica set to acknowledge the index build. " +
+					"When exceeded, the provider waits as long again for the build to complete on the primary instead of failing, " +
+					"and warns that the replication was not confirmed.",
+				Optional: true,
+			},
+			"collation": collationAttribute("Index collation."),
+			"sphere_index_version": schema.Int64Attribute{
+				Description: "Version of 2dsphere indexes (2dsphereIndexVersion), from 1 to 3. Defaults to the latest version " +
+					"supported by the server, assigned on creation.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 3),
+				},
+			},
+			"bits": schema.Int64Attribute{
+				Description: "Precision of the geohash of 2d indexes, in bits. Defaults to 26.",
 				Optional:    true,
-				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
-				},
-				Attributes: map[string]schema.Attribute{
-					"locale": schema.StringAttribute{
-						Description: "The locale.",
-						Required:    true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"case_level": schema.BoolAttribute{
-						Description: "The case level.",
-						Optional:    true,
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
-					"case_first": schema.StringAttribute{
-						Description: "The case ordering.",
-						Optional:    true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"strength": schema.Int64Attribute{
-						Description: "The number of comparison levels to use.",
-						Optional:    true,
-						PlanModifiers: []planmodifier.Int64{
-							int64planmodifier.RequiresReplace(),
-						},
-					},
-					"numeric_ordering": schema.BoolAttribute{
-						Description: "Whether to order numbers based on numerical order and not collation order.",
-						Optional:    true,
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
-					"alternate": schema.StringAttribute{
-						Description: "Whether spaces and punctuation are considered base characters.",
-						Optional:    true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"max_variable": schema.StringAttribute{
-						Description: "Which characters are affected by alternate: 'shifted'.",
-						Optional:    true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"normalization": schema.BoolAttribute{
-						Description: "Causes text to be normalized into Unicode NFD.",
-						Optional:    true,
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
-					"backwards": schema.BoolAttribute{
-						Description: "Causes secondary differences to be considered in reverse order, as it is done in the French language.",
-						Optional:    true,
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+			},
+			"min": schema.Float64Attribute{
+				Description: "Lower bound of the coordinates of 2d indexes, inclusive. Defaults to -180.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"max": schema.Float64Attribute{
+				Description: "Upper bound of the coordinates of 2d indexes, exclusive. Defaults to 180.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"text_index_version": schema.Int64Attribute{
+				Description: "Version of text indexes (textIndexVersion), assigned by the server.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 				},
 			},
 			// see https://developer.hashicorp.com/terraform/plugin/framework/acctests#implement-id-attribute
@@ -266,6 +303,8 @@ func (r *indexResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:           true,
 				DeprecationMessage: "Just there for compatibility reasons",
 			},
+		}, Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
 	}
 }
@@ -280,19 +319,92 @@ func (r *indexResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
 	databaseName := plan.Database
 	collectionName := plan.Collection
 	indexName := plan.Name
+	defer checkTimeout(ctx, &resp.Diagnostics, "create", fmt.Sprintf("index %s.%s.%s", databaseName, collectionName, indexName), timeout)
 
 	tflog.Debug(ctx, fmt.Sprintf("Creating index %s.%s.%s", databaseName, collectionName, indexName))
 
-	keys := bson.D{}
-	for _, key := range plan.Keys {
-		keys = append(keys, bson.E{Key: key.Field, Value: convertToMongoIndexType(key.Type)})
+	r.client.checkDatabase(ctx, databaseName, resp.Diagnostics.AddError)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	db := r.client.Database(databaseName)
-	collection := db.Collection(collectionName)
+	keys := toMongoIndexKeys(plan.Keys)
+
+	var partialFilter bson.D
+	if plan.PartialFilterExpression != nil {
+		partialFilter, err = parseValidator(*plan.PartialFilterExpression)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("partial_filter_expression"),
+				"Invalid partial filter expression",
+				"The partial filter expression must be a document in MongoDB Extended JSON.\n\nError: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	db := r.client.ddlDatabase(databaseName)
+	collectionOptions := options.Collection()
+	if plan.WTimeoutSeconds != nil {
+		//nolint:staticcheck // wtimeout is the only way to bound the replication of the index build
+		collectionOptions.SetWriteConcern(&writeconcern.WriteConcern{W: "majority", WTimeout: time.Duration(*plan.WTimeoutSeconds) * time.Second})
+	}
+	collection := db.Collection(collectionName, collectionOptions)
+
+	// Creating an index which exists succeeds silently, and creating one with the keys of another fails with
+	// an obscure error; both mostly happen when two resources declare the same index.
+	var existing []indexDocument
+	cursor, err := collection.Indexes().List(ctx)
+	if err == nil {
+		err = cursor.All(ctx, &existing)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list indexes",
+			"An unexpected error occurred when listing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	if conflict, reason := plan.findConflictingIndex(existing); conflict != nil {
+		resp.Diagnostics.AddError(
+			"Index conflict",
+			fmt.Sprintf("Index %s already exists on %s.%s %s. It is likely declared by another mongodb_index resource, "+
+				"or was created outside Terraform. Please keep a single resource for this index, or import the existing index with: "+
+				"terraform import <resource address> %s.%s.%s", conflict.Name, databaseName, collectionName, reason,
+				databaseName, collectionName, conflict.Name),
+		)
+		return
+	}
+
+	createOptions := options.CreateIndexes()
+	if r.client.maxTime > 0 {
+		//nolint:staticcheck // maxTimeMS bounds the build server side, whatever the client context
+		createOptions.SetMaxTime(r.client.maxTime)
+	}
 
 	options := &options.IndexOptions{
 		Name:               &indexName,
@@ -305,19 +417,64 @@ func (r *indexResource) Create(ctx context.Context, req resource.CreateRequest,
 	if plan.WildcardProjection != nil {
 		options.WildcardProjection = plan.WildcardProjection
 	}
+	if partialFilter != nil {
+		options.PartialFilterExpression = partialFilter
+	}
+	if !plan.SphereIndexVersion.IsUnknown() && !plan.SphereIndexVersion.IsNull() {
+		options.SetSphereVersion(int32(plan.SphereIndexVersion.ValueInt64()))
+	}
+	options.Bits = plan.Bits
+	options.Min = plan.Min
+	options.Max = plan.Max
 
-	name, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: options})
+	name, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: keys, Options: options}, createOptions)
+	if isWriteConcernTimeout(err) && plan.WTimeoutSeconds != nil {
+		// The build goes on server side, wait for it as long again instead of failing.
+		tflog.Warn(ctx, fmt.Sprintf("Write concern timed out for index %s.%s.%s, waiting for the build to complete", databaseName, collectionName, indexName))
+		name = indexName
+		err = waitForIndex(ctx, collection, indexName, time.Duration(*plan.WTimeoutSeconds)*time.Second)
+		if err == nil {
+			resp.Diagnostics.AddWarning(
+				"Index replication not confirmed",
+				fmt.Sprintf("Index %s.%s.%s is built, but its replication to a majority of the replica set was not "+
+					"acknowledged within w_timeout_seconds. The build goes on server side on the other members.", databaseName, collectionName, indexName),
+			)
+		}
+	}
+	if isMaxTimeExpired(err) {
+		resp.Diagnostics.AddError(
+			"Index build exceeded max_time_ms",
+			fmt.Sprintf("The server aborted the build of index %s.%s.%s after the provider max_time_ms of %d ms. "+
+				"Increase max_time_ms, or build the index when the server is less loaded.",
+				databaseName, collectionName, indexName, r.client.maxTime.Milliseconds()),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create index",
 			"An unexpected error occurred when creating index. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
+	// 2dsphere and text indexes are versioned by the server.
+	created, err := findIndex(ctx, collection, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list indexes",
+			"An unexpected error occurred when listing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	plan.readVersions(created)
+
 	plan.Name = name
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
 	plan.Id = types.StringValue("to_be_ignored")
 
 	// Set state to fully populated data
@@ -330,6 +487,396 @@ func (r *indexResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Debug(ctx, fmt.Sprintf("Index %s.%s.%s created", databaseName, collectionName, indexName))
 }
 
+// collationAttribute is the schema of a collation, which can only be changed by recreating the resource.
+func collationAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+		Optional:    true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"locale": schema.StringAttribute{
+				Description: "The locale.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"case_level": schema.BoolAttribute{
+				Description: "The case level.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"case_first": schema.StringAttribute{
+				Description: "The case ordering.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"strength": schema.Int64Attribute{
+				Description: "The number of comparison levels to use.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"numeric_ordering": schema.BoolAttribute{
+				Description: "Whether to order numbers based on numerical order and not collation order.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"alternate": schema.StringAttribute{
+				Description: "Whether spaces and punctuation are considered base characters.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_variable": schema.StringAttribute{
+				Description: "Which characters are affected by alternate: 'shifted'.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"normalization": schema.BoolAttribute{
+				Description: "Causes text to be normalized into Unicode NFD.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"backwards": schema.BoolAttribute{
+				Description: "Causes secondary differences to be considered in reverse order, as it is done in the French language.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the index specification locally, to report invalid indexes at plan time rather
+// than when the server rejects them on apply.
+func (r *indexResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var keysValue types.List
+	var expireAfterSeconds types.Int64
+	var unique types.Bool
+	var sparse types.Bool
+	var partialFilter types.String
+	var wildcardProjection types.Map
+	var collationValue types.Object
+	var sphereIndexVersion types.Int64
+	var bits types.Int64
+	var minimum, maximum types.Float64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("keys"), &keysValue)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expire_after_seconds"), &expireAfterSeconds)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("unique"), &unique)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sparse"), &sparse)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("partial_filter_expression"), &partialFilter)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wildcard_projection"), &wildcardProjection)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("collation"), &collationValue)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sphere_index_version"), &sphereIndexVersion)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bits"), &bits)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("min"), &minimum)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max"), &maximum)...)
+	if resp.Diagnostics.HasError() || keysValue.IsNull() || keysValue.IsUnknown() {
+		return
+	}
+
+	// Keys with unknown fields or types are only validated on apply.
+	var keys []indexKey
+	if diags := keysValue.ElementsAs(ctx, &keys, false); diags.HasError() {
+		return
+	}
+
+	spec := indexSpec{
+		keys:                  keys,
+		ttl:                   !expireAfterSeconds.IsNull(),
+		unique:                unique.ValueBool(),
+		sparse:                sparse.ValueBool(),
+		partial:               !partialFilter.IsNull(),
+		hasWildcardProjection: !wildcardProjection.IsNull(),
+		hasSphereVersion:      !sphereIndexVersion.IsNull(),
+	}
+	if !bits.IsNull() {
+		spec.options2d = append(spec.options2d, "bits")
+	}
+	if !minimum.IsNull() {
+		spec.options2d = append(spec.options2d, "min")
+	}
+	if !maximum.IsNull() {
+		spec.options2d = append(spec.options2d, "max")
+	}
+	if !minimum.IsNull() && !minimum.IsUnknown() && !maximum.IsNull() && !maximum.IsUnknown() {
+		spec.min, spec.max = minimum.ValueFloat64Pointer(), maximum.ValueFloat64Pointer()
+	}
+	// A collation with unknown options is only validated on apply.
+	if !collationValue.IsNull() && !collationValue.IsUnknown() {
+		var co collation
+		if diags := collationValue.As(ctx, &co, basetypes.ObjectAsOptions{}); !diags.HasError() {
+			spec.collation = &co
+		}
+	}
+	resp.Diagnostics.Append(spec.validate()...)
+}
+
+// indexSpec is the part of an index definition checked by validate.
+type indexSpec struct {
+	keys                  []indexKey
+	ttl                   bool
+	unique                bool
+	sparse                bool
+	partial               bool
+	hasWildcardProjection bool
+	collation             *collation
+	// hasSphereVersion is whether the 2dsphere index version is set, and options2d the names of the options of 2d
+	// indexes set, with the bounds when both are known.
+	hasSphereVersion bool
+	options2d        []string
+	min, max         *float64
+}
+
+// validIndexTypes are the key types accepted by the index resource.
+var validIndexTypes = map[string]bool{
+	"asc":      true,
+	"desc":     true,
+	"2d":       true,
+	"2dsphere": true,
+	"hashed":   true,
+	"text":     true,
+}
+
+// validateSpecialKeys checks how the special key types, i.e. other than asc and desc, are combined in a compound
+// index: a single special type per index, the 2d field first, and the text fields contiguous since the server
+// indexes them together as a single key.
+func validateSpecialKeys(keys []indexKey) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	specialType := ""
+	textEnded := false
+	for i, key := range keys {
+		keyPath := path.Root("keys").AtListIndex(i)
+		if key.Type == "asc" || key.Type == "desc" || !validIndexTypes[key.Type] {
+			textEnded = textEnded || specialType == "text"
+			continue
+		}
+
+		switch {
+		case specialType != "" && specialType != key.Type:
+			diags.AddAttributeError(
+				keyPath.AtName("type"),
+				"Mixed special index types",
+				fmt.Sprintf("Field %s is indexed as %s while field(s) before are indexed as %s. An index can only combine "+
+					"asc and desc fields with a single special type.", key.Field, key.Type, specialType),
+			)
+		case key.Type == "2d" && (i != 0 || specialType != ""):
+			diags.AddAttributeError(
+				keyPath.AtName("type"),
+				"Invalid 2d index",
+				fmt.Sprintf("Field %s must be the first and only 2d field of the index.", key.Field),
+			)
+		case key.Type == "text" && textEnded:
+			diags.AddAttributeError(
+				keyPath.AtName("type"),
+				"Non contiguous text fields",
+				fmt.Sprintf("Field %s is separated from the other text fields. The text fields of an index are indexed "+
+					"together, they must follow each other, with asc and desc fields only before or after them.", key.Field),
+			)
+		}
+		if specialType == "" {
+			specialType = key.Type
+		}
+	}
+
+	return diags
+}
+
+// validate checks the key types and the option combinations the server would reject.
+func (s *indexSpec) validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	fields := make(map[string]bool)
+	hashedKeys := 0
+	for i, key := range s.keys {
+		keyPath := path.Root("keys").AtListIndex(i)
+		if !validIndexTypes[key.Type] {
+			diags.AddAttributeError(
+				keyPath.AtName("type"),
+				"Invalid index type",
+				fmt.Sprintf("Index type %q of field %s is not supported, expected one of asc, desc, 2d, 2dsphere, hashed or text.", key.Type, key.Field),
+			)
+		}
+		if fields[key.Field] {
+			diags.AddAttributeError(
+				keyPath.AtName("field"),
+				"Duplicate index field",
+				fmt.Sprintf("Field %s is indexed more than once.", key.Field),
+			)
+		}
+		fields[key.Field] = true
+		if key.Type == "hashed" {
+			hashedKeys++
+		}
+	}
+
+	diags.Append(validateSpecialKeys(s.keys)...)
+
+	if hashedKeys > 1 {
+		diags.AddAttributeError(
+			path.Root("keys"),
+			"Multiple hashed fields",
+			"A compound index can contain a single hashed field.",
+		)
+	}
+	if hashedKeys > 0 && s.unique {
+		diags.AddAttributeError(
+			path.Root("unique"),
+			"Unique hashed index",
+			"Hashed indexes cannot be unique.",
+		)
+	}
+
+	if s.ttl {
+		if len(s.keys) != 1 {
+			diags.AddAttributeError(
+				path.Root("expire_after_seconds"),
+				"Compound ttl index",
+				"A ttl index must have a single field.",
+			)
+		} else if s.keys[0].Field == "_id" {
+			diags.AddAttributeError(
+				path.Root("expire_after_seconds"),
+				"Ttl index on _id",
+				"The _id field does not support ttl indexes.",
+			)
+		}
+	}
+
+	if s.sparse && s.partial {
+		diags.AddAttributeError(
+			path.Root("partial_filter_expression"),
+			"Sparse partial index",
+			"An index cannot be both sparse and partial. A partial filter such as {\"<field>\": {\"$exists\": true}} "+
+				"indexes the same documents as a sparse index.",
+		)
+	}
+
+	if s.hasSphereVersion && !s.hasKeyType("2dsphere") {
+		diags.AddAttributeError(
+			path.Root("sphere_index_version"),
+			"Sphere index version without 2dsphere index",
+			"The 2dsphere index version requires a 2dsphere field.",
+		)
+	}
+	if !s.hasKeyType("2d") {
+		for _, name := range s.options2d {
+			diags.AddAttributeError(
+				path.Root(name),
+				"2d index option without 2d index",
+				fmt.Sprintf("Option %s only applies to 2d indexes, and requires a 2d field.", name),
+			)
+		}
+	}
+	if s.min != nil && s.max != nil && *s.min >= *s.max {
+		diags.AddAttributeError(
+			path.Root("max"),
+			"Invalid 2d index bounds",
+			fmt.Sprintf("The upper bound %g must be greater than the lower bound %g.", *s.max, *s.min),
+		)
+	}
+
+	if s.hasWildcardProjection && (len(s.keys) != 1 || s.keys[0].Field != "$**") {
+		diags.AddAttributeError(
+			path.Root("wildcard_projection"),
+			"Wildcard projection without wildcard index",
+			"A wildcard projection requires the index to have the single field $**.",
+		)
+	}
+
+	diags.Append(s.collation.validate(path.Root("collation"))...)
+	if s.unique && s.collation != nil && s.collation.Strength != nil && *s.collation.Strength < 3 {
+		diags.AddAttributeWarning(
+			path.Root("unique"),
+			"Case-insensitive unique index",
+			"The collation strength makes the index unique regardless of case, e.g. Foo and foo are duplicates. "+
+				"The uniqueness only holds through this collation: keep it on the index, and specify the same collation "+
+				"in queries for them to use the index.",
+		)
+	}
+
+	return diags
+}
+
+// hasKeyType checks whether a field of the index has the key type.
+func (s *indexSpec) hasKeyType(keyType string) bool {
+	for _, key := range s.keys {
+		if key.Type == keyType {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the collation options values and combinations the server would reject.
+func (co *collation) validate(collationPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if co == nil {
+		return diags
+	}
+
+	if co.Strength != nil && (*co.Strength < 1 || *co.Strength > 5) {
+		diags.AddAttributeError(
+			collationPath.AtName("strength"),
+			"Invalid collation strength",
+			fmt.Sprintf("Collation strength %d is not supported, expected a value between 1 and 5.", *co.Strength),
+		)
+	}
+	if co.CaseFirst != nil && *co.CaseFirst != "upper" && *co.CaseFirst != "lower" && *co.CaseFirst != "off" {
+		diags.AddAttributeError(
+			collationPath.AtName("case_first"),
+			"Invalid collation case first",
+			fmt.Sprintf("Collation case first %q is not supported, expected one of upper, lower or off.", *co.CaseFirst),
+		)
+	}
+	if co.Alternate != nil && *co.Alternate != "non-ignorable" && *co.Alternate != "shifted" {
+		diags.AddAttributeError(
+			collationPath.AtName("alternate"),
+			"Invalid collation alternate",
+			fmt.Sprintf("Collation alternate %q is not supported, expected one of non-ignorable or shifted.", *co.Alternate),
+		)
+	}
+	if co.MaxVariable != nil {
+		if *co.MaxVariable != "punct" && *co.MaxVariable != "space" {
+			diags.AddAttributeError(
+				collationPath.AtName("max_variable"),
+				"Invalid collation max variable",
+				fmt.Sprintf("Collation max variable %q is not supported, expected one of punct or space.", *co.MaxVariable),
+			)
+		} else if co.Alternate == nil || *co.Alternate != "shifted" {
+			diags.AddAttributeError(
+				collationPath.AtName("max_variable"),
+				"Collation max variable without shifted alternate",
+				"Collation max variable is only used when alternate is shifted.",
+			)
+		}
+	}
+
+	return diags
+}
+
+// ModifyPlan keeps the namespace consistent with the database and collection of the index.
+func (r *indexResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planNamespace(ctx, req, resp, "collection")
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (r *indexResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
@@ -340,73 +887,97 @@ func (r *indexResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, release := r.client.withSession(ctx)
+	defer release()
+
 	databaseName := state.Database
 	collectionName := state.Collection
 	indexName := state.Name
 
 	tflog.Debug(ctx, fmt.Sprintf("Getting index %s.%s.%s", databaseName, collectionName, indexName))
 
-	db := r.client.Database(databaseName)
+	db := r.client.readDatabase(databaseName)
 	collection := db.Collection(collectionName)
-	indexes, err := collection.Indexes().ListSpecifications(ctx)
+	foundIndex, err := findIndex(ctx, collection, indexName)
+	// The indexes of a dropped collection are dropped with it.
+	if isNamespaceNotFound(err) {
+		foundIndex, err = nil, nil
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to list indexes",
 			"An unexpected error occurred when listing indexes. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
-	var foundIndex *mongo.IndexSpecification
-	for _, index := range indexes {
-		if index.Name == indexName {
-			foundIndex = index
-			break
-		}
-	}
-
 	if foundIndex == nil {
-		resp.Diagnostics.AddError(
-			"Unable to find index with name "+indexName,
-			"The requested index does not exist. ",
-		)
+		tflog.Warn(ctx, fmt.Sprintf("Index %s.%s.%s not found, removing it from the state", databaseName, collectionName, indexName))
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Found index %s.%s.%s", databaseName, collectionName, indexName))
 
-	var foundKeys bson.D
-	err = bson.Unmarshal(foundIndex.KeysDocument, &foundKeys)
+	foundKeys, err := foundIndex.keys()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to parse keys from fetched index",
 			"An unexpected error occurred when parsing index keys. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
-	state.Keys = make([]indexKey, 0)
-	for _, v := range foundKeys {
-		typ, err := convertToTfIndexType(v.Value)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to convert key type from fetched index",
-				"An unexpected error occurred when parsing index keys. "+
-					"If the error is not clear, please contact the provider developers.\n\n"+
-					"Error: "+err.Error(),
-			)
-			return
-		}
-		state.Keys = append(state.Keys, indexKey{Field: v.Key, Type: typ})
+	// The server keeps the text fields of text indexes in its own order, keep the configured one.
+	if !sameIndexKeys(state.Keys, foundKeys) {
+		state.Keys = foundKeys
+	}
+
+	foundCollation, err := fromMongoCollation(foundIndex.Collation)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse collation from fetched index",
+			"An unexpected error occurred when parsing index collation. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	// The server fills in the collation options left to their default, keep the configured ones while they match.
+	if !state.Collation.matches(foundCollation) {
+		state.Collation = foundCollation
 	}
 
-	state.Sparse = foundIndex.Sparse
+	state.Sparse = readBoolOption(state.Sparse, foundIndex.Sparse)
 	state.ExpireAfterSeconds = foundIndex.ExpireAfterSeconds
-	state.Unique = foundIndex.Unique
+	state.Unique = readBoolOption(state.Unique, foundIndex.Unique)
+	state.PartialFilterExpression, err = readPartialFilter(state.PartialFilterExpression, foundIndex.PartialFilterExpression)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse partial filter expression from fetched index",
+			"An unexpected error occurred when parsing index partial filter expression. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.WildcardProjection, err = readWildcardProjection(foundIndex.WildcardProjection)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse wildcard projection from fetched index",
+			"An unexpected error occurred when parsing index wildcard projection. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.readVersions(foundIndex)
+	state.Bits, state.Min, state.Max = foundIndex.Bits, foundIndex.Min, foundIndex.Max
+	state.Namespace = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
 	state.Id = types.StringValue("to_be_ignored")
 
 	// Set refreshed state
@@ -421,10 +992,62 @@ func (r *indexResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *indexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"An update has been triggered when none should have been.",
-		" Changes in index should always result in resource recreation. ",
-	)
+	// Changes in index result in resource recreation, except for the ttl of ttl indexes which is changed
+	// through collMod, and w_timeout_seconds which only applies to creation and is just saved in the state.
+	var plan, state indexResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := plan.Database
+	collectionName := plan.Collection
+	indexName := plan.Name
+	defer checkTimeout(ctx, &resp.Diagnostics, "update", fmt.Sprintf("index %s.%s.%s", databaseName, collectionName, indexName), timeout)
+
+	if plan.ExpireAfterSeconds != nil && !reflect.DeepEqual(plan.ExpireAfterSeconds, state.ExpireAfterSeconds) {
+		tflog.Debug(ctx, fmt.Sprintf("Updating expireAfterSeconds of index %s.%s.%s", databaseName, collectionName, indexName))
+
+		err := r.client.Database(databaseName).RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: collectionName},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: indexName},
+				{Key: "expireAfterSeconds", Value: *plan.ExpireAfterSeconds},
+			}},
+		}).Err()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update index",
+				"An unexpected error occurred when updating index. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -437,22 +1060,63 @@ func (r *indexResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
 	// Delete index
 	databaseName := state.Database
 	collectionName := state.Collection
 	indexName := state.Name
+	defer checkTimeout(ctx, &resp.Diagnostics, "delete", fmt.Sprintf("index %s.%s.%s", databaseName, collectionName, indexName), timeout)
 
 	tflog.Debug(ctx, fmt.Sprintf("Dropping index %s.%s.%s", databaseName, collectionName, indexName))
 
 	db := r.client.Database(databaseName)
 	collection := db.Collection(collectionName)
 
-	_, err := collection.Indexes().DropOne(ctx, indexName)
+	_, err = collection.Indexes().DropOne(ctx, indexName)
+	if isIndexNotFound(err) {
+		// The index may have been renamed out-of-band, look for the index with the same keys and options.
+		tflog.Debug(ctx, fmt.Sprintf("Index %s.%s.%s not found, looking for an index with the same keys and options", databaseName, collectionName, indexName))
+
+		renamedIndex, findErr := findRenamedIndex(ctx, collection, &state)
+		switch {
+		case findErr != nil:
+			err = findErr
+		case renamedIndex == "":
+			tflog.Warn(ctx, fmt.Sprintf("Index %s.%s.%s already dropped", databaseName, collectionName, indexName))
+			err = nil
+		default:
+			tflog.Debug(ctx, fmt.Sprintf("Dropping index %s.%s.%s which has the same keys and options", databaseName, collectionName, renamedIndex))
+			_, err = collection.Indexes().DropOne(ctx, renamedIndex)
+		}
+	}
+	if isNamespaceNotFound(err) {
+		// The index was dropped with its collection.
+		tflog.Warn(ctx, fmt.Sprintf("Collection %s.%s of index %s already dropped", databaseName, collectionName, indexName))
+		err = nil
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update (drop) index",
 			"An unexpected error occurred when creating index. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
@@ -468,7 +1132,7 @@ func (r *indexResource) ImportState(ctx context.Context, req resource.ImportStat
 		resp.Diagnostics.AddError(
 			"Invalid id format. Should be <database>.<collection>.<index_name>.",
 			"An unexpected error occurred when creating index. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+				reportFooter()+
 				"Error: "+err.Error(),
 		)
 		return
@@ -478,3 +1142,226 @@ func (r *indexResource) ImportState(ctx context.Context, req resource.ImportStat
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection"), id.collection)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.indexName)...)
 }
+
+// Find the name of the index on the collection having the keys and options of the index in state, which may
+// have been renamed, empty when none does. At most one index must match, to never drop the index of another resource.
+func findRenamedIndex(ctx context.Context, collection *mongo.Collection, state *indexResourceModel) (string, error) {
+	var documents []indexDocument
+	cursor, err := collection.Indexes().List(ctx)
+	if err == nil {
+		err = cursor.All(ctx, &documents)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	for _, document := range documents {
+		if document.Name != "_id_" && state.matches(&document) {
+			matches = append(matches, document.Name)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", nil
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("indexes %v all have the keys and options of index %s, "+
+			"drop the right one manually then remove it from the state", matches, state.Name)
+	}
+}
+
+// findIndex returns the index with the given name, nil if none.
+func findIndex(ctx context.Context, collection *mongo.Collection, name string) (*indexDocument, error) {
+	var documents []indexDocument
+	cursor, err := collection.Indexes().List(ctx)
+	if err == nil {
+		err = cursor.All(ctx, &documents)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range documents {
+		if documents[i].Name == name {
+			return &documents[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// readVersions sets the versions the server assigned to the index, null when it has none or was not found.
+func (m *indexResourceModel) readVersions(document *indexDocument) {
+	m.SphereIndexVersion = types.Int64Null()
+	m.TextIndexVersion = types.Int64Null()
+	if document == nil {
+		return
+	}
+	if document.SphereIndexVersion != nil {
+		m.SphereIndexVersion = types.Int64Value(int64(*document.SphereIndexVersion))
+	}
+	if document.TextIndexVersion != nil {
+		m.TextIndexVersion = types.Int64Value(int64(*document.TextIndexVersion))
+	}
+}
+
+// findConflictingIndex returns the existing index which has the name of the index, or its keys and collation,
+// along with the reason of the conflict, nil if none.
+func (m *indexResourceModel) findConflictingIndex(existing []indexDocument) (*indexDocument, string) {
+	for i := range existing {
+		document := &existing[i]
+		if document.Name == m.Name {
+			return document, "with the same name"
+		}
+		// Indexes with the same keys but different partial filters can coexist.
+		if document.Name == "_id_" || !samePartialFilter(m.PartialFilterExpression, document.PartialFilterExpression) {
+			continue
+		}
+		keys, err := document.keys()
+		if err != nil || !sameIndexKeys(keys, m.Keys) {
+			continue
+		}
+		found, err := fromMongoCollation(document.Collation)
+		if err == nil && m.Collation.matches(found) {
+			return document, "with the same keys"
+		}
+	}
+	return nil, ""
+}
+
+// Check whether an index listed by the server has the keys and options of the index in state, its name aside.
+func (m *indexResourceModel) matches(document *indexDocument) bool {
+	keys, err := document.keys()
+	if err != nil || !sameIndexKeys(keys, m.Keys) {
+		return false
+	}
+	if document.Unique != (m.Unique != nil && *m.Unique) || document.Sparse != (m.Sparse != nil && *m.Sparse) {
+		return false
+	}
+	if !reflect.DeepEqual(document.ExpireAfterSeconds, m.ExpireAfterSeconds) || !samePartialFilter(m.PartialFilterExpression, document.PartialFilterExpression) {
+		return false
+	}
+
+	wildcardProjection, err := readWildcardProjection(document.WildcardProjection)
+	if err != nil || !reflect.DeepEqual(wildcardProjection, m.WildcardProjection) {
+		return false
+	}
+
+	found, err := fromMongoCollation(document.Collation)
+	return err == nil && m.Collation.matches(found)
+}
+
+// Check whether a collation returned by the server has the options set in the collation in state, the server
+// filling in the options left to their default.
+func (co *collation) matches(found *collation) bool {
+	if co == nil || found == nil {
+		return co == nil && found == nil
+	}
+	return co.Locale == found.Locale &&
+		optionMatches(co.CaseLevel, found.CaseLevel) &&
+		optionMatches(co.CaseFirst, found.CaseFirst) &&
+		optionMatches(co.Strength, found.Strength) &&
+		optionMatches(co.NumericOrdering, found.NumericOrdering) &&
+		optionMatches(co.Alternate, found.Alternate) &&
+		optionMatches(co.MaxVariable, found.MaxVariable) &&
+		optionMatches(co.Normalization, found.Normalization) &&
+		optionMatches(co.Backwards, found.Backwards)
+}
+
+// samePartialFilter checks whether the partial filter of an index listed by the server is the configured one once
+// parsed, both being unset for regular indexes.
+func samePartialFilter(current *string, found bson.Raw) bool {
+	if current == nil || len(found) == 0 {
+		return current == nil && len(found) == 0
+	}
+	document, err := parseValidator(*current)
+	if err != nil {
+		return false
+	}
+	raw, err := bson.Marshal(document)
+	return err == nil && bytes.Equal(raw, found)
+}
+
+// readPartialFilter converts the partial filter of an index listed by the server into canonical Extended JSON,
+// nil for regular indexes. The current filter is kept while it is equivalent, so that the formatting of the
+// configuration is not replaced.
+func readPartialFilter(current *string, found bson.Raw) (*string, error) {
+	if len(found) == 0 {
+		return nil, nil
+	}
+	if samePartialFilter(current, found) {
+		return current, nil
+	}
+	filter, err := bson.MarshalExtJSON(found, true, false)
+	if err != nil {
+		return nil, err
+	}
+	converted := string(filter)
+	return &converted, nil
+}
+
+// readWildcardProjection converts the wildcard projection of an index listed by the server, nil when it has none.
+// Other clients may have written the fields as booleans or doubles rather than integers.
+func readWildcardProjection(found bson.Raw) (*map[string]int32, error) {
+	if len(found) == 0 {
+		return nil, nil
+	}
+	elements, err := found.Elements()
+	if err != nil {
+		return nil, err
+	}
+	projection := make(map[string]int32)
+	for _, element := range elements {
+		if included, ok := element.Value().BooleanOK(); ok {
+			projection[element.Key()] = 0
+			if included {
+				projection[element.Key()] = 1
+			}
+			continue
+		}
+		value, ok := element.Value().AsInt64OK()
+		if !ok {
+			return nil, fmt.Errorf("unexpected value %s of wildcard projection field %s", element.Value(), element.Key())
+		}
+		projection[element.Key()] = int32(value)
+	}
+	return &projection, nil
+}
+
+// readBoolOption returns the value of a boolean index option read from the server, which omits false options.
+// An option set to false is kept as false rather than read as unset.
+func readBoolOption(current *bool, found bool) *bool {
+	if !found && current == nil {
+		return nil
+	}
+	return &found
+}
+
+func optionMatches[T comparable](want *T, found *T) bool {
+	return want == nil || (found != nil && *want == *found)
+}
+
+// Wait at most timeout until the index is listed, which happens once its build completes on the queried member.
+func waitForIndex(ctx context.Context, collection *mongo.Collection, indexName string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		indexes, err := collection.Indexes().ListSpecifications(ctx)
+		if err != nil {
+			return err
+		}
+		for _, index := range indexes {
+			if index.Name == indexName {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("index %s is still not built: %w", indexName, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -1,9 +1,28 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func TestAccIndexResource(t *testing.T) {
@@ -86,3 +105,1696 @@ resource "mongodb_index" "acc_test" {
 		},
 	})
 }
+
+// testAccDeleteIndex runs the Delete of the index resource with the given state.
+func testAccDeleteIndex(t *testing.T, client *mongo.Client, model *indexResourceModel) diag.Diagnostics {
+	ctx := context.Background()
+	r := &indexResource{client: &mongodbClient{Client: client}}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	model.Id = types.StringValue("to_be_ignored")
+	model.Timeouts = testNullTimeouts()
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, model)
+	if diags.HasError() {
+		t.Fatalf("Unable to set state: %v", diags)
+	}
+
+	var resp fwresource.DeleteResponse
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+	return resp.Diagnostics
+}
+
+// testNullTimeouts returns unset timeouts, for models set in a state or plan.
+func testNullTimeouts() timeouts.Value {
+	return timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	})}
+}
+
+// testAccIndexNames lists the names of the indexes of the collection.
+func testAccIndexNames(t *testing.T, collection *mongo.Collection) []string {
+	specifications, err := collection.Indexes().ListSpecifications(context.Background())
+	if err != nil {
+		t.Fatalf("Unable to list indexes: %v", err)
+	}
+	names := make([]string, 0, len(specifications))
+	for _, specification := range specifications {
+		names = append(names, specification.Name)
+	}
+	return names
+}
+
+func TestAccIndexResourceDeleteRenamed(t *testing.T) {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+
+	ctx := context.Background()
+	client := testAccClient(t)
+	collection := client.Database("test").Collection("test_renamed")
+	_ = collection.Drop(ctx)
+	keys := []indexKey{{Field: "field1", Type: "asc"}}
+	strength := 2
+
+	// The index is managed as tf_acc_test_renamed but was renamed out-of-band, another index has the same
+	// keys with a different collation.
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: toMongoIndexKeys(keys), Options: options.Index().SetName("renamed_out_of_band").SetCollation(&options.Collation{Locale: "en", Strength: 2})},
+		{Keys: toMongoIndexKeys(keys), Options: options.Index().SetName("other_collation").SetCollation(&options.Collation{Locale: "fr"})},
+	})
+	if err != nil {
+		t.Fatalf("Unable to create indexes: %v", err)
+	}
+
+	diags := testAccDeleteIndex(t, client, &indexResourceModel{
+		Database:   "test",
+		Collection: "test_renamed",
+		Name:       "tf_acc_test_renamed",
+		Keys:       keys,
+		Collation:  &collation{Locale: "en", Strength: &strength},
+	})
+	if diags.HasError() {
+		t.Fatalf("Unable to delete index: %v", diags)
+	}
+
+	if names := testAccIndexNames(t, collection); !reflect.DeepEqual(names, []string{"_id_", "other_collation"}) {
+		t.Fatalf("Expected only the renamed index to be dropped, found %v", names)
+	}
+
+	// No index has the options of the index in state anymore.
+	diags = testAccDeleteIndex(t, client, &indexResourceModel{
+		Database:   "test",
+		Collection: "test_renamed",
+		Name:       "tf_acc_test_renamed",
+		Keys:       keys,
+		Collation:  &collation{Locale: "en", Strength: &strength},
+	})
+	if !diags.HasError() {
+		t.Fatalf("Expected an error when no index matches")
+	}
+	if names := testAccIndexNames(t, collection); len(names) != 2 {
+		t.Fatalf("Expected no index to be dropped, found %v", names)
+	}
+}
+
+func TestAccIndexResourceDeleteRenamedAmbiguous(t *testing.T) {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+
+	ctx := context.Background()
+	client := testAccClient(t)
+	collection := client.Database("test").Collection("test_renamed_ambiguous")
+	_ = collection.Drop(ctx)
+	keys := []indexKey{{Field: "field1", Type: "asc"}}
+
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: toMongoIndexKeys(keys), Options: options.Index().SetName("first").SetCollation(&options.Collation{Locale: "en"})},
+		{Keys: toMongoIndexKeys(keys), Options: options.Index().SetName("second").SetCollation(&options.Collation{Locale: "en", Strength: 2})},
+	})
+	if err != nil {
+		t.Fatalf("Unable to create indexes: %v", err)
+	}
+
+	// Both indexes have the locale of the index in state, which leaves the strength to its default.
+	diags := testAccDeleteIndex(t, client, &indexResourceModel{
+		Database:   "test",
+		Collection: "test_renamed_ambiguous",
+		Name:       "tf_acc_test_renamed",
+		Keys:       keys,
+		Collation:  &collation{Locale: "en"},
+	})
+	if !diags.HasError() {
+		t.Fatalf("Expected an error when several indexes match")
+	}
+	if names := testAccIndexNames(t, collection); len(names) != 3 {
+		t.Fatalf("Expected no index to be dropped, found %v", names)
+	}
+}
+
+func TestIndexResourceModelMatches(t *testing.T) {
+	keys := []indexKey{{Field: "a", Type: "asc"}}
+	keysDocument, _ := bson.Marshal(toMongoIndexKeys(keys))
+	collationDocument, _ := bson.Marshal(bson.D{{Key: "locale", Value: "en"}, {Key: "strength", Value: 3}, {Key: "caseLevel", Value: false}})
+	filterDocument, _ := bson.Marshal(bson.D{{Key: "a", Value: bson.D{{Key: "$gt", Value: 1}}}})
+	unique := true
+	strength := 2
+	filter, otherFilter := `{"a": {"$gt": 1}}`, `{"a": {"$gt": 2}}`
+
+	cases := []struct {
+		name     string
+		model    indexResourceModel
+		document indexDocument
+		want     bool
+	}{
+		{"same keys", indexResourceModel{Keys: keys}, indexDocument{Key: keysDocument}, true},
+		{"other keys", indexResourceModel{Keys: []indexKey{{Field: "a", Type: "desc"}}}, indexDocument{Key: keysDocument}, false},
+		{"unique", indexResourceModel{Keys: keys, Unique: &unique}, indexDocument{Key: keysDocument, Unique: true}, true},
+		{"not unique", indexResourceModel{Keys: keys}, indexDocument{Key: keysDocument, Unique: true}, false},
+		{"sparse", indexResourceModel{Keys: keys}, indexDocument{Key: keysDocument, Sparse: true}, false},
+		{"partial", indexResourceModel{Keys: keys}, indexDocument{Key: keysDocument, PartialFilterExpression: filterDocument}, false},
+		{"same partial", indexResourceModel{Keys: keys, PartialFilterExpression: &filter}, indexDocument{Key: keysDocument, PartialFilterExpression: filterDocument}, true},
+		{"other partial", indexResourceModel{Keys: keys, PartialFilterExpression: &otherFilter}, indexDocument{Key: keysDocument, PartialFilterExpression: filterDocument}, false},
+		{"no partial", indexResourceModel{Keys: keys, PartialFilterExpression: &filter}, indexDocument{Key: keysDocument}, false},
+		{"collation defaults", indexResourceModel{Keys: keys, Collation: &collation{Locale: "en"}}, indexDocument{Key: keysDocument, Collation: collationDocument}, true},
+		{"collation strength", indexResourceModel{Keys: keys, Collation: &collation{Locale: "en", Strength: &strength}}, indexDocument{Key: keysDocument, Collation: collationDocument}, false},
+		{"no collation", indexResourceModel{Keys: keys}, indexDocument{Key: keysDocument, Collation: collationDocument}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.model.matches(&c.document); got != c.want {
+				t.Fatalf("Expected %t, got %t", c.want, got)
+			}
+		})
+	}
+}
+
+func TestIndexResourceModelFindConflictingIndex(t *testing.T) {
+	keys := []indexKey{{Field: "a", Type: "asc"}}
+	keysDocument, _ := bson.Marshal(toMongoIndexKeys(keys))
+	otherKeysDocument, _ := bson.Marshal(toMongoIndexKeys([]indexKey{{Field: "b", Type: "asc"}}))
+	idKeysDocument, _ := bson.Marshal(bson.D{{Key: "_id", Value: 1}})
+	collationDocument, _ := bson.Marshal(bson.D{{Key: "locale", Value: "en"}, {Key: "strength", Value: 3}})
+	filterDocument, _ := bson.Marshal(bson.D{{Key: "a", Value: bson.D{{Key: "$gt", Value: 1}}}})
+
+	cases := []struct {
+		name     string
+		existing []indexDocument
+		want     string
+		reason   string
+	}{
+		{"no index", []indexDocument{{Name: "_id_", Key: idKeysDocument}}, "", ""},
+		{"same name", []indexDocument{{Name: "a_index", Key: otherKeysDocument}}, "a_index", "with the same name"},
+		{"same keys", []indexDocument{{Name: "other", Key: keysDocument}}, "other", "with the same keys"},
+		{"other keys", []indexDocument{{Name: "other", Key: otherKeysDocument}}, "", ""},
+		{"other collation", []indexDocument{{Name: "other", Key: keysDocument, Collation: collationDocument}}, "", ""},
+		{"partial", []indexDocument{{Name: "other", Key: keysDocument, PartialFilterExpression: filterDocument}}, "", ""},
+	}
+
+	model := indexResourceModel{Name: "a_index", Keys: keys}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			conflict, reason := model.findConflictingIndex(c.existing)
+			name := ""
+			if conflict != nil {
+				name = conflict.Name
+			}
+			if name != c.want || reason != c.reason {
+				t.Errorf("Expected conflict %q %q, got %q %q", c.want, c.reason, name, reason)
+			}
+		})
+	}
+
+	// A partial index only conflicts with an index with the same keys and filter.
+	filter := `{"a": {"$gt": 1}}`
+	partial := indexResourceModel{Name: "a_index", Keys: keys, PartialFilterExpression: &filter}
+	if conflict, _ := partial.findConflictingIndex([]indexDocument{{Name: "other", Key: keysDocument}}); conflict != nil {
+		t.Errorf("Expected no conflict with the regular index, got %s", conflict.Name)
+	}
+	conflict, reason := partial.findConflictingIndex([]indexDocument{{Name: "other", Key: keysDocument, PartialFilterExpression: filterDocument}})
+	if conflict == nil || reason != "with the same keys" {
+		t.Errorf("Expected a conflict with the partial index with the same filter, got %v %q", conflict, reason)
+	}
+}
+
+func TestReadPartialFilter(t *testing.T) {
+	found, _ := bson.Marshal(bson.D{{Key: "count", Value: bson.D{{Key: "$gte", Value: int64(1)}}}})
+
+	// The configured filter is kept while equivalent.
+	current := `{ "count": { "$gte": { "$numberLong": "1" } } }`
+	filter, err := readPartialFilter(&current, found)
+	if err != nil || filter != &current {
+		t.Errorf("Expected the current filter to be kept, got %v and %v", filter, err)
+	}
+
+	// A filter changed outside Terraform is read in canonical Extended JSON.
+	other := `{"count": {"$gte": 2}}`
+	filter, err = readPartialFilter(&other, found)
+	if err != nil || filter == nil || *filter != `{"count":{"$gte":{"$numberLong":"1"}}}` {
+		t.Errorf("Expected the canonical filter, got %v and %v", filter, err)
+	}
+
+	// A regular index has no filter.
+	if filter, err = readPartialFilter(&current, nil); filter != nil || err != nil {
+		t.Errorf("Expected no filter, got %v and %v", filter, err)
+	}
+}
+
+func TestAccIndexResourceConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "first" {
+	database = "test_conflict"
+	collection = "test"
+	name = "first"
+	keys = [{ field = "a", type = "asc" }]
+}
+
+resource "mongodb_index" "second" {
+	database = "test_conflict"
+	collection = "test"
+	name = "second"
+	keys = [{ field = "a", type = "asc" }]
+
+	depends_on = [mongodb_index.first]
+}
+`,
+				ExpectError: regexp.MustCompile("Index conflict"),
+			},
+		},
+	})
+}
+
+func TestReadBoolOption(t *testing.T) {
+	value := func(v bool) *bool { return &v }
+	cases := []struct {
+		current *bool
+		found   bool
+		want    *bool
+	}{
+		{nil, false, nil},
+		{nil, true, value(true)},
+		{value(false), false, value(false)},
+		{value(true), true, value(true)},
+		{value(true), false, value(false)},
+	}
+
+	for _, c := range cases {
+		if got := readBoolOption(c.current, c.found); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Expected %v for %v and %t, got %v", c.want, c.current, c.found, got)
+		}
+	}
+}
+
+func TestIndexSpecValidateCaseInsensitiveUnique(t *testing.T) {
+	keys := []indexKey{{Field: "email", Type: "asc"}}
+	strength := func(v int) *int { return &v }
+
+	cases := []struct {
+		name        string
+		spec        indexSpec
+		wantWarning bool
+	}{
+		{"unique without collation", indexSpec{keys: keys, unique: true}, false},
+		{"unique case-sensitive", indexSpec{keys: keys, unique: true, collation: &collation{Locale: "en", Strength: strength(3)}}, false},
+		{"unique case-insensitive", indexSpec{keys: keys, unique: true, collation: &collation{Locale: "en", Strength: strength(2)}}, true},
+		{"case-insensitive", indexSpec{keys: keys, collation: &collation{Locale: "en", Strength: strength(2)}}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := c.spec.validate()
+			if diags.HasError() || (diags.WarningsCount() == 1) != c.wantWarning {
+				t.Errorf("Expected warning %t, got diagnostics %v", c.wantWarning, diags)
+			}
+		})
+	}
+}
+
+func TestAccIndexResourcePartialFilter(t *testing.T) {
+	ctx := context.Background()
+	config := func(filter string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "mongodb_index" "test" {
+  database   = "test_partial"
+  collection = "accounts"
+  name       = "email_active"
+  keys = [
+    {
+      "field" : "email"
+      "type" : "asc"
+    }
+  ]
+  unique                    = true
+  partial_filter_expression = %q
+}
+`, filter)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`{"status": "active"}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "partial_filter_expression", `{"status": "active"}`),
+					func(_ *terraform.State) error {
+						// Only the active documents are checked for uniqueness.
+						collection := testAccClient(t).Database("test_partial").Collection("accounts")
+						_, err := collection.InsertMany(ctx, []interface{}{
+							bson.D{{Key: "email", Value: "a@example.com"}, {Key: "status", Value: "active"}},
+							bson.D{{Key: "email", Value: "a@example.com"}, {Key: "status", Value: "closed"}},
+						})
+						if err != nil {
+							return fmt.Errorf("expected an inactive duplicate to be inserted, got %w", err)
+						}
+						_, err = collection.InsertOne(ctx, bson.D{{Key: "email", Value: "a@example.com"}, {Key: "status", Value: "active"}})
+						if !mongo.IsDuplicateKeyError(err) {
+							return fmt.Errorf("expected a duplicate key error for an active duplicate, got %v", err)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "mongodb_index.test",
+				ImportStateId:     "test_partial.accounts.email_active",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The filter is imported in canonical Extended JSON, without the spaces of the configuration.
+				ImportStateVerifyIgnore: []string{"partial_filter_expression"},
+			},
+			// The filter cannot be changed in place.
+			{
+				Config: config(`{"status": {"$in": ["active", "pending"]}}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "test" {
+  database   = "test_partial"
+  collection = "accounts"
+  name       = "email_active"
+  keys = [
+    {
+      "field" : "email"
+      "type" : "asc"
+    }
+  ]
+  sparse                    = true
+  partial_filter_expression = "{\"status\": \"active\"}"
+}
+`,
+				ExpectError: regexp.MustCompile("Sparse partial index"),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceSparse(t *testing.T) {
+	ctx := context.Background()
+	config := func(sparse bool) string {
+		return providerConfig + fmt.Sprintf(`
+resource "mongodb_index" "test" {
+  database   = "test_sparse"
+  collection = "users"
+  name       = "nickname"
+  keys = [
+    {
+      "field" : "nickname"
+      "type" : "asc"
+    }
+  ]
+  unique = true
+  sparse = %t
+}
+`, sparse)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "sparse", "true"),
+					func(_ *terraform.State) error {
+						// Documents without the field are not indexed, so they are not duplicates of each other.
+						collection := testAccClient(t).Database("test_sparse").Collection("users")
+						_, err := collection.InsertMany(ctx, []interface{}{
+							bson.D{{Key: "name", Value: "a"}},
+							bson.D{{Key: "name", Value: "b"}},
+						})
+						if err != nil {
+							return fmt.Errorf("expected documents without nickname to be inserted, got %w", err)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "mongodb_index.test",
+				ImportStateId:     "test_sparse.users.nickname",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Sparse indexes cannot be changed in place. The documents without nickname are now duplicates.
+			{
+				Config: config(false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.test", plancheck.ResourceActionReplace),
+					},
+				},
+				ExpectError: regexp.MustCompile("E11000"),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceCollation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "test" {
+  database   = "test_index_collation"
+  collection = "products"
+  name       = "reference"
+  keys = [
+    {
+      "field" : "reference"
+      "type" : "asc"
+    }
+  ]
+  collation = {
+    locale           = "fr"
+    case_level       = true
+    case_first       = "upper"
+    strength         = 1
+    numeric_ordering = true
+    alternate        = "shifted"
+    max_variable     = "space"
+    normalization    = true
+    backwards        = true
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "collation.locale", "fr"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "collation.numeric_ordering", "true"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "collation.max_variable", "space"),
+				),
+			},
+			// With all its options set, the collation is imported as configured.
+			{
+				ResourceName:      "mongodb_index.test",
+				ImportStateId:     "test_index_collation.products.reference",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Collations cannot be changed in place.
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "test" {
+  database   = "test_index_collation"
+  collection = "products"
+  name       = "reference"
+  keys = [
+    {
+      "field" : "reference"
+      "type" : "asc"
+    }
+  ]
+  collation = {
+    locale   = "fr"
+    strength = 2
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.test", plancheck.ResourceActionReplace),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceCaseInsensitiveUnique(t *testing.T) {
+	ctx := context.Background()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "test" {
+  database   = "test_case_insensitive"
+  collection = "users"
+  name       = "email_unique"
+  keys = [
+    {
+      "field" : "email"
+      "type" : "asc"
+    }
+  ]
+  unique = true
+  collation = {
+    locale   = "en"
+    strength = 2
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "unique", "true"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "collation.strength", "2"),
+					func(_ *terraform.State) error {
+						collection := testAccClient(t).Database("test_case_insensitive").Collection("users")
+						if _, err := collection.InsertOne(ctx, bson.D{{Key: "email", Value: "Foo"}}); err != nil {
+							return fmt.Errorf("unable to insert the first document: %w", err)
+						}
+						_, err := collection.InsertOne(ctx, bson.D{{Key: "email", Value: "foo"}})
+						if !mongo.IsDuplicateKeyError(err) {
+							return fmt.Errorf("expected a duplicate key error when inserting foo after Foo, got %v", err)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestReadWildcardProjection(t *testing.T) {
+	found, err := readWildcardProjection(nil)
+	if err != nil || found != nil {
+		t.Errorf("Expected no projection, got %v, %v", found, err)
+	}
+
+	// Written by other clients, e.g. the shell which writes numbers as doubles.
+	document, _ := bson.Marshal(bson.D{{Key: "attributes", Value: 1.0}, {Key: "tags", Value: true}, {Key: "_id", Value: int32(0)}})
+	found, err = readWildcardProjection(document)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]int32{"attributes": 1, "tags": 1, "_id": 0}
+	if found == nil || !reflect.DeepEqual(*found, expected) {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+
+	document, _ = bson.Marshal(bson.D{{Key: "attributes", Value: "yes"}})
+	if _, err = readWildcardProjection(document); err == nil {
+		t.Error("Expected an error for a string projection")
+	}
+}
+
+func TestAccIndexResourceWildcard(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "test" {
+  database   = "test_wildcard"
+  collection = "products"
+  name       = "attributes"
+  keys       = [{ field = "$**", type = "asc" }]
+  wildcard_projection = {
+    "attributes" = 1
+    "tags"       = 1
+  }
+}
+
+resource "mongodb_index" "path" {
+  database   = "test_wildcard"
+  collection = "products"
+  name       = "details"
+  keys       = [{ field = "details.$**", type = "asc" }]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "keys.0.field", "$**"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "wildcard_projection.%", "2"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "wildcard_projection.attributes", "1"),
+					resource.TestCheckResourceAttr("mongodb_index.path", "keys.0.field", "details.$**"),
+					resource.TestCheckNoResourceAttr("mongodb_index.path", "wildcard_projection"),
+				),
+			},
+			// The projection is read back on import.
+			{
+				ResourceName:      "mongodb_index.test",
+				ImportStateId:     "test_wildcard.products.attributes",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "mongodb_index.path",
+				ImportStateId:     "test_wildcard.products.details",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceCompoundOrder(t *testing.T) {
+	ctx := context.Background()
+	config := func(first string, second string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "mongodb_index" "test" {
+  database   = "test_compound_order"
+  collection = "invoices"
+  name       = "compound"
+  keys = [
+    { field = %q, type = "asc" },
+    { field = %q, type = "desc" },
+    { field = "month", type = "asc" },
+  ]
+}
+`, first, second)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("zone", "account"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "keys.0.field", "zone"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "keys.1.field", "account"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "keys.1.type", "desc"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "keys.2.field", "month"),
+					func(_ *terraform.State) error {
+						found, err := findIndex(ctx, testAccClient(t).Database("test_compound_order").Collection("invoices"), "compound")
+						if err != nil || found == nil {
+							return fmt.Errorf("expected index compound, got %v, %v", found, err)
+						}
+						keys, err := found.keys()
+						if err != nil {
+							return err
+						}
+						expected := []indexKey{{Field: "zone", Type: "asc"}, {Field: "account", Type: "desc"}, {Field: "month", Type: "asc"}}
+						if !reflect.DeepEqual(keys, expected) {
+							return fmt.Errorf("expected keys %v on the server, got %v", expected, keys)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "mongodb_index.test",
+				ImportState:       true,
+				ImportStateId:     "test_compound_order.invoices.compound",
+				ImportStateVerify: true,
+			},
+			// Swapping two fields makes another index.
+			{
+				Config: config("account", "zone"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mongodb_index.test", "keys.0.field", "account"),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceCompoundUnique(t *testing.T) {
+	ctx := context.Background()
+	config := func(unique bool) string {
+		return providerConfig + fmt.Sprintf(`
+resource "mongodb_index" "test" {
+  database   = "test_compound_unique"
+  collection = "memberships"
+  name       = "team_user"
+  keys = [
+    {
+      "field" : "team"
+      "type" : "asc"
+    },
+    {
+      "field" : "user"
+      "type" : "asc"
+    }
+  ]
+  unique = %t
+}
+`, unique)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "unique", "true"),
+					func(_ *terraform.State) error {
+						collection := testAccClient(t).Database("test_compound_unique").Collection("memberships")
+						_, err := collection.InsertMany(ctx, []interface{}{
+							bson.D{{Key: "team", Value: "a"}, {Key: "user", Value: "x"}},
+							bson.D{{Key: "team", Value: "a"}, {Key: "user", Value: "y"}},
+							bson.D{{Key: "team", Value: "b"}, {Key: "user", Value: "x"}},
+						})
+						if err != nil {
+							return fmt.Errorf("expected distinct pairs to be inserted, got %w", err)
+						}
+						_, err = collection.InsertOne(ctx, bson.D{{Key: "team", Value: "a"}, {Key: "user", Value: "x"}})
+						if !mongo.IsDuplicateKeyError(err) {
+							return fmt.Errorf("expected a duplicate key error when inserting the same pair, got %v", err)
+						}
+						return nil
+					},
+				),
+			},
+			// Uniqueness cannot be changed in place.
+			{
+				Config: config(false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "unique", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestIndexDocumentImmediateTTL(t *testing.T) {
+	// Shells send numbers as doubles, a ttl of 0 may be stored as such.
+	for _, ttl := range []interface{}{int32(0), float64(0)} {
+		raw, _ := bson.Marshal(bson.D{{Key: "name", Value: "ttl"}, {Key: "expireAfterSeconds", Value: ttl}})
+		var document indexDocument
+		if err := bson.Unmarshal(raw, &document); err != nil {
+			t.Fatalf("Unable to parse index: %v", err)
+		}
+		if document.ExpireAfterSeconds == nil || *document.ExpireAfterSeconds != 0 {
+			t.Errorf("Expected a ttl of 0 for %T, got %v", ttl, document.ExpireAfterSeconds)
+		}
+	}
+
+	raw, _ := bson.Marshal(bson.D{{Key: "name", Value: "regular"}})
+	var document indexDocument
+	if err := bson.Unmarshal(raw, &document); err != nil {
+		t.Fatalf("Unable to parse index: %v", err)
+	}
+	if document.ExpireAfterSeconds != nil {
+		t.Errorf("Expected no ttl, got %d", *document.ExpireAfterSeconds)
+	}
+}
+
+func TestAccIndexResourceImmediateTTL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "ttl" {
+	database = "test_ttl"
+	collection = "immediate"
+	name = "expire_at"
+	keys = [{ field = "expire_at", type = "asc" }]
+	expire_after_seconds = 0
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.ttl", "expire_after_seconds", "0"),
+					func(_ *terraform.State) error {
+						var documents []indexDocument
+						cursor, err := testAccClient(t).Database("test_ttl").Collection("immediate").Indexes().List(context.Background())
+						if err == nil {
+							err = cursor.All(context.Background(), &documents)
+						}
+						if err != nil {
+							return err
+						}
+						for _, document := range documents {
+							if document.Name == "expire_at" && document.ExpireAfterSeconds != nil && *document.ExpireAfterSeconds == 0 {
+								return nil
+							}
+						}
+						return fmt.Errorf("expected index expire_at to have a ttl of 0, got %+v", documents)
+					},
+				),
+			},
+			{
+				ResourceName:      "mongodb_index.ttl",
+				ImportStateId:     "test_ttl.immediate.expire_at",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestIndexDocumentTextKeys(t *testing.T) {
+	key, _ := bson.Marshal(bson.D{
+		{Key: "category", Value: int32(1)},
+		{Key: "_fts", Value: "text"},
+		{Key: "_ftsx", Value: int32(1)},
+	})
+	weights, _ := bson.Marshal(bson.D{
+		{Key: "body", Value: int32(1)},
+		{Key: "title", Value: int32(1)},
+	})
+	version := int32(3)
+	document := indexDocument{Name: "search", Key: key, Weights: weights, TextIndexVersion: &version}
+
+	keys, err := document.keys()
+	want := []indexKey{
+		{Field: "category", Type: "asc"},
+		{Field: "body", Type: "text"},
+		{Field: "title", Type: "text"},
+	}
+	if err != nil || !reflect.DeepEqual(keys, want) {
+		t.Fatalf("Expected %v, got %v, err %v", want, keys, err)
+	}
+
+	model := indexResourceModel{Keys: []indexKey{want[0], want[2], want[1]}}
+	if !model.matches(&document) {
+		t.Errorf("Expected the index to match whatever the order of its text fields")
+	}
+
+	model.readVersions(&document)
+	if model.TextIndexVersion.ValueInt64() != 3 || !model.SphereIndexVersion.IsNull() {
+		t.Errorf("Expected text index version 3 only, got %v and %v", model.TextIndexVersion, model.SphereIndexVersion)
+	}
+	model.readVersions(nil)
+	if !model.TextIndexVersion.IsNull() {
+		t.Errorf("Expected no version without index, got %v", model.TextIndexVersion)
+	}
+}
+
+func TestAccIndexResourceVersions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "location" {
+	database = "test_versions"
+	collection = "places"
+	name = "location"
+	keys = [{ field = "location", type = "2dsphere" }]
+}
+
+resource "mongodb_index" "search" {
+	database = "test_versions"
+	collection = "places"
+	name = "search"
+	keys = [
+		{ field = "name", type = "text" },
+		{ field = "description", type = "text" },
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mongodb_index.location", "sphere_index_version"),
+					resource.TestCheckNoResourceAttr("mongodb_index.location", "text_index_version"),
+					resource.TestCheckResourceAttrSet("mongodb_index.search", "text_index_version"),
+					resource.TestCheckNoResourceAttr("mongodb_index.search", "sphere_index_version"),
+					resource.TestCheckResourceAttr("mongodb_index.search", "keys.0.field", "name"),
+				),
+			},
+			{
+				ResourceName:      "mongodb_index.location",
+				ImportStateId:     "test_versions.places.location",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:            "mongodb_index.search",
+				ImportStateId:           "test_versions.places.search",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"keys"},
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceGeospatialOptions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "sphere" {
+	database = "test_geo"
+	collection = "places"
+	name = "location"
+	keys = [{ field = "location", type = "2dsphere" }]
+	sphere_index_version = 2
+}
+
+resource "mongodb_index" "grid" {
+	database = "test_geo"
+	collection = "tiles"
+	name = "position"
+	keys = [{ field = "position", type = "2d" }]
+	bits = 20
+	min = 0
+	max = 1024
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.sphere", "sphere_index_version", "2"),
+					resource.TestCheckResourceAttr("mongodb_index.grid", "bits", "20"),
+					resource.TestCheckResourceAttr("mongodb_index.grid", "min", "0"),
+					resource.TestCheckResourceAttr("mongodb_index.grid", "max", "1024"),
+				),
+			},
+			{
+				ResourceName:      "mongodb_index.grid",
+				ImportStateId:     "test_geo.tiles.position",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing the version recreates the index, removing it keeps the version of the index.
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "sphere" {
+	database = "test_geo"
+	collection = "places"
+	name = "location"
+	keys = [{ field = "location", type = "2dsphere" }]
+	sphere_index_version = 3
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.sphere", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mongodb_index.sphere", "sphere_index_version", "3"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "sphere" {
+	database = "test_geo"
+	collection = "places"
+	name = "location"
+	keys = [{ field = "location", type = "2dsphere" }]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceMixedCompound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "mixed" {
+	database = "test_mixed"
+	collection = "places"
+	name = "category_location"
+	keys = [
+		{ field = "category", type = "asc" },
+		{ field = "location", type = "2dsphere" },
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.mixed", "keys.1.type", "2dsphere"),
+					func(_ *terraform.State) error {
+						ctx := context.Background()
+						collection := testAccClient(t).Database("test_mixed").Collection("places")
+						_, err := collection.InsertOne(ctx, bson.D{
+							{Key: "category", Value: "cafe"},
+							{Key: "location", Value: bson.D{{Key: "type", Value: "Point"}, {Key: "coordinates", Value: bson.A{2.35, 48.85}}}},
+						})
+						if err != nil {
+							return err
+						}
+						// The hint makes the query fail if the index cannot serve it.
+						count, err := collection.CountDocuments(ctx, bson.D{
+							{Key: "category", Value: "cafe"},
+							{Key: "location", Value: bson.D{{Key: "$geoWithin", Value: bson.D{
+								{Key: "$centerSphere", Value: bson.A{bson.A{2.35, 48.85}, 0.001}},
+							}}}},
+						}, options.Count().SetHint("category_location"))
+						if err != nil {
+							return err
+						}
+						if count != 1 {
+							return fmt.Errorf("expected 1 document near the point, got %d", count)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "mixed" {
+	database = "test_mixed"
+	collection = "places"
+	name = "category_location"
+	keys = [
+		{ field = "category", type = "text" },
+		{ field = "location", type = "2dsphere" },
+	]
+}
+`,
+				ExpectError: regexp.MustCompile(`Mixed special index types`),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceTimeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "timeouts" {
+	database = "test_timeouts"
+	collection = "documents"
+	name = "a"
+	keys = [{ field = "a", type = "asc" }]
+	timeouts {
+		create = "1ns"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Timeout exceeded`),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "timeouts" {
+	database = "test_timeouts"
+	collection = "documents"
+	name = "a"
+	keys = [{ field = "a", type = "asc" }]
+	timeouts {
+		create = "5m"
+		delete = "2m"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.timeouts", "timeouts.create", "5m"),
+					resource.TestCheckResourceAttr("mongodb_index.timeouts", "timeouts.delete", "2m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceMaxTime(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			// Enough documents for the build to take more than a millisecond.
+			collection := testAccClient(t).Database("test_max_time").Collection("documents")
+			documents := make([]interface{}, 0, 50000)
+			for i := 0; i < 50000; i++ {
+				documents = append(documents, bson.D{{Key: "a", Value: i}, {Key: "b", Value: fmt.Sprintf("value %d", i)}})
+			}
+			if _, err := collection.InsertMany(context.Background(), documents); err != nil {
+				t.Fatalf("Unable to seed collection: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "mongodb" {
+  host = "localhost"
+  port = "27017"
+  username = "test"
+  password = "test"
+  max_time_ms = 1
+}
+
+resource "mongodb_index" "slow" {
+	database = "test_max_time"
+	collection = "documents"
+	name = "a_b"
+	keys = [{ field = "a", type = "asc" }, { field = "b", type = "desc" }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Index build exceeded max_time_ms`),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceWTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "wtimeout" {
+  database          = "test"
+  collection        = "test_wtimeout"
+  name              = "tf_acc_test_wtimeout"
+  w_timeout_seconds = 1
+  keys = [
+    {
+      "field" : "field1"
+      "type" : "asc"
+    }
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.wtimeout", "name", "tf_acc_test_wtimeout"),
+					resource.TestCheckResourceAttr("mongodb_index.wtimeout", "w_timeout_seconds", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceNamespace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "test" {
+	namespace = "test_namespace.indexed"
+	collection = "indexed"
+	name = "test_index"
+	keys = [{ field = "a", type = "asc" }]
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "test" {
+	namespace = "test_namespace.indexed"
+	name = "test_index"
+	keys = [{ field = "a", type = "asc" }]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_index.test", "database", "test_namespace"),
+					resource.TestCheckResourceAttr("mongodb_index.test", "collection", "indexed"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceTTLDrift(t *testing.T) {
+	ctx := context.Background()
+	config := providerConfig + `
+resource "mongodb_index" "ttl" {
+	database = "test_ttl"
+	collection = "events"
+	name = "ttl_index"
+	keys = [{ field = "created_at", type = "asc" }]
+	expire_after_seconds = 3600
+}
+`
+	indexTTL := func() (*int32, error) {
+		specs, err := testAccClient(t).Database("test_ttl").Collection("events").Indexes().ListSpecifications(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range specs {
+			if spec.Name == "ttl_index" {
+				return spec.ExpireAfterSeconds, nil
+			}
+		}
+		return nil, fmt.Errorf("index ttl_index not found")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("mongodb_index.ttl", "expire_after_seconds", "3600"),
+			},
+			{
+				PreConfig: func() {
+					err := testAccClient(t).Database("test_ttl").RunCommand(ctx, bson.D{
+						{Key: "collMod", Value: "events"},
+						{Key: "index", Value: bson.D{{Key: "name", Value: "ttl_index"}, {Key: "expireAfterSeconds", Value: 60}}},
+					}).Err()
+					if err != nil {
+						t.Fatalf("Unable to change the index ttl: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.ttl", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: func(_ *terraform.State) error {
+					ttl, err := indexTTL()
+					if err != nil {
+						return err
+					}
+					if ttl == nil || *ttl != 3600 {
+						return fmt.Errorf("expected the index ttl to be reconciled to 3600, got %v", ttl)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceDropped(t *testing.T) {
+	ctx := context.Background()
+	config := providerConfig + `
+resource "mongodb_index" "dropped" {
+	database = "test_index_dropped"
+	collection = "events"
+	name = "kind"
+	keys = [{ field = "kind", type = "asc" }]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// An index dropped outside Terraform is created again rather than failing the plan.
+			{
+				PreConfig: func() {
+					_, err := testAccClient(t).Database("test_index_dropped").Collection("events").Indexes().DropOne(ctx, "kind")
+					if err != nil {
+						t.Fatalf("Unable to drop index: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.dropped", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			// So is an index of a dropped collection.
+			{
+				PreConfig: func() {
+					if err := testAccClient(t).Database("test_index_dropped").Collection("events").Drop(ctx); err != nil {
+						t.Fatalf("Unable to drop collection: %v", err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.dropped", plancheck.ResourceActionCreate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccIndexResourceTTLUpdate(t *testing.T) {
+	config := func(ttl string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "mongodb_index" "ttl" {
+	database = "test_ttl"
+	collection = "sessions"
+	name = "ttl_index"
+	keys = [{ field = "last_seen", type = "asc" }]
+	%s
+}
+`, ttl)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("expire_after_seconds = 3600"),
+				Check:  resource.TestCheckResourceAttr("mongodb_index.ttl", "expire_after_seconds", "3600"),
+			},
+			// The ttl is changed in place with collMod.
+			{
+				Config: config("expire_after_seconds = 7200"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.ttl", plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mongodb_index.ttl", "expire_after_seconds", "7200"),
+			},
+			// Removing the ttl makes a regular index, which requires recreating it.
+			{
+				Config: config(""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_index.ttl", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckNoResourceAttr("mongodb_index.ttl", "expire_after_seconds"),
+			},
+		},
+	})
+}
+
+func TestIndexSpecValidate(t *testing.T) {
+	bound := func(v float64) *float64 { return &v }
+
+	cases := []struct {
+		name      string
+		spec      indexSpec
+		expectErr bool
+	}{
+		{
+			name: "compound",
+			spec: indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}, {Field: "b", Type: "desc"}}, unique: true},
+		},
+		{
+			name: "ttl",
+			spec: indexSpec{keys: []indexKey{{Field: "created_at", Type: "asc"}}, ttl: true},
+		},
+		{
+			name: "wildcard projection",
+			spec: indexSpec{keys: []indexKey{{Field: "$**", Type: "asc"}}, hasWildcardProjection: true},
+		},
+		{
+			name: "2dsphere compound",
+			spec: indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}, {Field: "b", Type: "2dsphere"}, {Field: "c", Type: "2dsphere"}}},
+		},
+		{
+			name: "2d compound",
+			spec: indexSpec{keys: []indexKey{{Field: "location", Type: "2d"}, {Field: "b", Type: "desc"}}},
+		},
+		{
+			name: "text compound",
+			spec: indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}, {Field: "b", Type: "text"}, {Field: "c", Type: "text"}, {Field: "d", Type: "desc"}}},
+		},
+		{
+			name:      "text and 2dsphere",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "text"}, {Field: "b", Type: "2dsphere"}}},
+			expectErr: true,
+		},
+		{
+			name:      "2d not first",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}, {Field: "location", Type: "2d"}}},
+			expectErr: true,
+		},
+		{
+			name:      "multiple 2d",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "2d"}, {Field: "b", Type: "2d"}}},
+			expectErr: true,
+		},
+		{
+			name:      "non contiguous text",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "text"}, {Field: "b", Type: "asc"}, {Field: "c", Type: "text"}}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid type",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "ascending"}}},
+			expectErr: true,
+		},
+		{
+			name:      "duplicate field",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}, {Field: "a", Type: "desc"}}},
+			expectErr: true,
+		},
+		{
+			name:      "unique hashed",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "hashed"}}, unique: true},
+			expectErr: true,
+		},
+		{
+			name:      "multiple hashed",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "hashed"}, {Field: "b", Type: "hashed"}}},
+			expectErr: true,
+		},
+		{
+			name:      "compound ttl",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}, {Field: "b", Type: "asc"}}, ttl: true},
+			expectErr: true,
+		},
+		{
+			name:      "ttl on _id",
+			spec:      indexSpec{keys: []indexKey{{Field: "_id", Type: "asc"}}, ttl: true},
+			expectErr: true,
+		},
+		{
+			name: "partial",
+			spec: indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}}, unique: true, partial: true},
+		},
+		{
+			name: "ttl partial",
+			spec: indexSpec{keys: []indexKey{{Field: "created_at", Type: "asc"}}, ttl: true, partial: true},
+		},
+		{
+			name: "2d options",
+			spec: indexSpec{keys: []indexKey{{Field: "location", Type: "2d"}}, options2d: []string{"bits", "min", "max"}, min: bound(-90.0), max: bound(90.0)},
+		},
+		{
+			name:      "2d options without 2d",
+			spec:      indexSpec{keys: []indexKey{{Field: "location", Type: "2dsphere"}}, options2d: []string{"bits"}},
+			expectErr: true,
+		},
+		{
+			name:      "2d inverted bounds",
+			spec:      indexSpec{keys: []indexKey{{Field: "location", Type: "2d"}}, options2d: []string{"min", "max"}, min: bound(10.0), max: bound(-10.0)},
+			expectErr: true,
+		},
+		{
+			name: "sphere version",
+			spec: indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}, {Field: "location", Type: "2dsphere"}}, hasSphereVersion: true},
+		},
+		{
+			name:      "sphere version without 2dsphere",
+			spec:      indexSpec{keys: []indexKey{{Field: "location", Type: "2d"}}, hasSphereVersion: true},
+			expectErr: true,
+		},
+		{
+			name:      "sparse partial",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}}, sparse: true, partial: true},
+			expectErr: true,
+		},
+		{
+			name:      "wildcard projection without wildcard",
+			spec:      indexSpec{keys: []indexKey{{Field: "a", Type: "asc"}}, hasWildcardProjection: true},
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diags := c.spec.validate()
+			if diags.HasError() != c.expectErr {
+				t.Errorf("Expected error %t, got diagnostics %v", c.expectErr, diags)
+			}
+		})
+	}
+}
+
+func TestIndexSpecValidateCollation(t *testing.T) {
+	value := func(v string) *string { return &v }
+	strength := func(v int) *int { return &v }
+	keys := []indexKey{{Field: "a", Type: "asc"}}
+
+	cases := []struct {
+		name      string
+		collation collation
+		want      []string
+	}{
+		{
+			name:      "valid",
+			collation: collation{Locale: "fr", Strength: strength(2), CaseFirst: value("upper"), Alternate: value("shifted"), MaxVariable: value("space")},
+		},
+		{
+			name:      "strength too low",
+			collation: collation{Locale: "fr", Strength: strength(0)},
+			want:      []string{"Invalid collation strength"},
+		},
+		{
+			name:      "strength too high",
+			collation: collation{Locale: "fr", Strength: strength(6)},
+			want:      []string{"Invalid collation strength"},
+		},
+		{
+			name:      "invalid case first",
+			collation: collation{Locale: "fr", CaseFirst: value("first")},
+			want:      []string{"Invalid collation case first"},
+		},
+		{
+			name:      "invalid alternate",
+			collation: collation{Locale: "fr", Alternate: value("ignorable")},
+			want:      []string{"Invalid collation alternate"},
+		},
+		{
+			name:      "invalid max variable",
+			collation: collation{Locale: "fr", Alternate: value("shifted"), MaxVariable: value("symbol")},
+			want:      []string{"Invalid collation max variable"},
+		},
+		{
+			name:      "max variable without alternate",
+			collation: collation{Locale: "fr", MaxVariable: value("punct")},
+			want:      []string{"Collation max variable without shifted alternate"},
+		},
+		{
+			name:      "max variable with non-ignorable alternate",
+			collation: collation{Locale: "fr", Alternate: value("non-ignorable"), MaxVariable: value("punct")},
+			want:      []string{"Collation max variable without shifted alternate"},
+		},
+		{
+			name:      "several errors",
+			collation: collation{Locale: "fr", Strength: strength(9), CaseFirst: value("none")},
+			want:      []string{"Invalid collation strength", "Invalid collation case first"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			spec := indexSpec{keys: keys, collation: &c.collation}
+			got := make([]string, 0)
+			for _, d := range spec.validate() {
+				got = append(got, d.Summary())
+			}
+			want := c.want
+			if want == nil {
+				want = []string{}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Expected diagnostics %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestAccWaitForIndexTimeout(t *testing.T) {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+
+	collection := testAccClient(t).Database("test").Collection("test_wait")
+	start := time.Now()
+	err := waitForIndex(context.Background(), collection, "never_built", 2*time.Second)
+	if err == nil {
+		t.Fatalf("Expected an error for an index which is never built")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected the wait to be bounded, waited %v", elapsed)
+	}
+}
+
+func TestAccIndexResourceDeleteDropped(t *testing.T) {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+
+	ctx := context.Background()
+	client := testAccClient(t)
+	r := &indexResource{client: &mongodbClient{Client: client}}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	deleteIndex := func(collectionName string) diag.Diagnostics {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+		state.Set(ctx, &indexResourceModel{
+			Namespace:          types.StringValue("test_index_dropped." + collectionName),
+			Database:           "test_index_dropped",
+			Collection:         collectionName,
+			Name:               "dropped",
+			Keys:               []indexKey{{Field: "a", Type: "asc"}},
+			SphereIndexVersion: types.Int64Null(),
+			TextIndexVersion:   types.Int64Null(),
+			Timeouts:           testNullTimeouts(),
+			Id:                 types.StringValue("to_be_ignored"),
+		})
+		resp := fwresource.DeleteResponse{State: state}
+		r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+		return resp.Diagnostics
+	}
+
+	// The index was dropped out-of-band, its collection still exists.
+	db := client.Database("test_index_dropped")
+	if err := db.CreateCollection(ctx, "existing"); err != nil {
+		t.Fatalf("Unable to create collection: %v", err)
+	}
+	defer func() { _ = db.Drop(ctx) }()
+	if diags := deleteIndex("existing"); diags.HasError() {
+		t.Errorf("Expected the dropped index to be deleted, got %v", diags)
+	}
+
+	// The index was dropped with its collection.
+	if diags := deleteIndex("missing"); diags.HasError() {
+		t.Errorf("Expected the index of a dropped collection to be deleted, got %v", diags)
+	}
+}
@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &indexesDataSource{}
+	_ datasource.DataSourceWithConfigure = &indexesDataSource{}
+)
+
+// indexesDataSource is the data source implementation.
+type indexesDataSource struct {
+	client *mongodbClient
+}
+
+// indexesDataSourceModel maps the data source schema data.
+type indexesDataSourceModel struct {
+	Database       string       `tfsdk:"database"`
+	Collection     string       `tfsdk:"collection"`
+	IncludeIdIndex *bool        `tfsdk:"include_id_index"`
+	Indexes        []indexInfo  `tfsdk:"indexes"`
+	Id             types.String `tfsdk:"id"`
+}
+
+type indexInfo struct {
+	Name               string     `tfsdk:"name"`
+	Keys               []indexKey `tfsdk:"keys"`
+	Unique             bool       `tfsdk:"unique"`
+	Sparse             bool       `tfsdk:"sparse"`
+	ExpireAfterSeconds *int32     `tfsdk:"expire_after_seconds"`
+	Collation          *collation `tfsdk:"collation"`
+}
+
+// indexDocument maps the index documents returned by listIndexes.
+type indexDocument struct {
+	Name                    string   `bson:"name"`
+	Key                     bson.Raw `bson:"key"`
+	Unique                  bool     `bson:"unique"`
+	Sparse                  bool     `bson:"sparse"`
+	ExpireAfterSeconds      *int32   `bson:"expireAfterSeconds"`
+	Collation               bson.Raw `bson:"collation"`
+	PartialFilterExpression bson.Raw `bson:"partialFilterExpression"`
+	WildcardProjection      bson.Raw `bson:"wildcardProjection"`
+	Weights                 bson.Raw `bson:"weights"`
+	SphereIndexVersion      *int32   `bson:"2dsphereIndexVersion"`
+	TextIndexVersion        *int32   `bson:"textIndexVersion"`
+	Bits                    *int32   `bson:"bits"`
+	Min                     *float64 `bson:"min"`
+	Max                     *float64 `bson:"max"`
+}
+
+// NewIndexesDataSource is a helper function to simplify the provider implementation.
+func NewIndexesDataSource() datasource.DataSource {
+	return &indexesDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *indexesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB indexes data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB indexes data source")
+}
+
+// Metadata returns the data source type name.
+func (d *indexesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_indexes"
+}
+
+// Schema defines the schema for the data source.
+func (d *indexesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the indexes of a collection.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database of the collection.",
+				Required:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "Name of the collection.",
+				Required:    true,
+			},
+			"include_id_index": schema.BoolAttribute{
+				Description: "Whether to list the _id_ index. Defaults to false.",
+				Optional:    true,
+			},
+			"indexes": schema.ListNestedAttribute{
+				Description: "Indexes of the collection.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the index.",
+							Computed:    true,
+						},
+						"keys": schema.ListNestedAttribute{
+							Description: "Fields composing the index, in order.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"field": schema.StringAttribute{
+										Description: "Name of the field.",
+										Computed:    true,
+									},
+									"type": schema.StringAttribute{
+										Description: "Type of the index on the field, e.g. asc, desc or text.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"unique": schema.BoolAttribute{
+							Description: "Is it a unique index.",
+							Computed:    true,
+						},
+						"sparse": schema.BoolAttribute{
+							Description: "Is it a sparse index.",
+							Computed:    true,
+						},
+						"expire_after_seconds": schema.Int64Attribute{
+							Description: "Documents ttl in seconds for ttl indexes.",
+							Computed:    true,
+						},
+						"collation": collationDataSourceAttribute("Index collation, as returned by the server."),
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// collationDataSourceAttribute returns the schema of a collation read from the server.
+func collationDataSourceAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description,
+		Computed:    true,
+		Attributes: map[string]schema.Attribute{
+			"locale": schema.StringAttribute{
+				Description: "The locale.",
+				Computed:    true,
+			},
+			"case_level": schema.BoolAttribute{
+				Description: "The case level.",
+				Computed:    true,
+			},
+			"case_first": schema.StringAttribute{
+				Description: "The case ordering.",
+				Computed:    true,
+			},
+			"strength": schema.Int64Attribute{
+				Description: "The number of comparison levels to use.",
+				Computed:    true,
+			},
+			"numeric_ordering": schema.BoolAttribute{
+				Description: "Whether to order numbers based on numerical order and not collation order.",
+				Computed:    true,
+			},
+			"alternate": schema.StringAttribute{
+				Description: "Whether spaces and punctuation are considered base characters.",
+				Computed:    true,
+			},
+			"max_variable": schema.StringAttribute{
+				Description: "Which characters are affected by alternate: 'shifted'.",
+				Computed:    true,
+			},
+			"normalization": schema.BoolAttribute{
+				Description: "Causes text to be normalized into Unicode NFD.",
+				Computed:    true,
+			},
+			"backwards": schema.BoolAttribute{
+				Description: "Causes secondary differences to be considered in reverse order, as it is done in the French language.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *indexesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state indexesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database
+	collectionName := state.Collection
+
+	tflog.Debug(ctx, fmt.Sprintf("Listing indexes of %s.%s", databaseName, collectionName))
+
+	var documents []indexDocument
+	cursor, err := d.client.Database(databaseName).Collection(collectionName).Indexes().List(ctx)
+	if err == nil {
+		err = cursor.All(ctx, &documents)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list indexes",
+			"An unexpected error occurred when listing indexes. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Indexes = make([]indexInfo, 0, len(documents))
+	for _, document := range documents {
+		if document.Name == "_id_" && (state.IncludeIdIndex == nil || !*state.IncludeIdIndex) {
+			continue
+		}
+
+		index, err := document.toIndexInfo()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to parse index "+document.Name,
+				"An unexpected error occurred when parsing index. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+		state.Indexes = append(state.Indexes, *index)
+	}
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Listed %d indexes of %s.%s", len(state.Indexes), databaseName, collectionName))
+}
+
+// keys returns the keys of the index as declared. The key of text indexes has _fts and _ftsx fields in place of
+// the text fields, which are the fields of the weights.
+func (d *indexDocument) keys() ([]indexKey, error) {
+	keys, err := toTfIndexKeys(d.Key)
+	if err != nil || len(d.Weights) == 0 {
+		return keys, err
+	}
+	weights, err := d.Weights.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]indexKey, 0, len(keys)+len(weights))
+	for _, key := range keys {
+		switch key.Field {
+		case "_fts":
+			for _, weight := range weights {
+				res = append(res, indexKey{Field: weight.Key(), Type: "text"})
+			}
+		case "_ftsx":
+		default:
+			res = append(res, key)
+		}
+	}
+	return res, nil
+}
+
+// toIndexInfo converts an index document, keeping the order of its keys.
+func (d *indexDocument) toIndexInfo() (*indexInfo, error) {
+	keys, err := d.keys()
+	if err != nil {
+		return nil, err
+	}
+	collation, err := fromMongoCollation(d.Collation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexInfo{
+		Name:               d.Name,
+		Keys:               keys,
+		Unique:             d.Unique,
+		Sparse:             d.Sparse,
+		ExpireAfterSeconds: d.ExpireAfterSeconds,
+		Collation:          collation,
+	}, nil
+}
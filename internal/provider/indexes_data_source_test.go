@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestAccIndexesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			collection := testAccClient(t).Database("test_indexes").Collection("listed")
+			_, err := collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+				Keys: bson.D{
+					{Key: "zeta", Value: 1},
+					{Key: "alpha", Value: -1},
+					{Key: "middle", Value: 1},
+				},
+				Options: options.Index().SetName("compound").SetUnique(true),
+			})
+			if err != nil {
+				t.Fatalf("Unable to create index: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_indexes" "test" {
+	database = "test_indexes"
+	collection = "listed"
+}
+
+data "mongodb_indexes" "with_id" {
+	database = "test_indexes"
+	collection = "listed"
+	include_id_index = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_indexes.test", "indexes.#", "1"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.test", "indexes.0.name", "compound"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.test", "indexes.0.unique", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.test", "indexes.0.keys.0.field", "zeta"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.test", "indexes.0.keys.0.type", "asc"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.test", "indexes.0.keys.1.field", "alpha"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.test", "indexes.0.keys.1.type", "desc"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.test", "indexes.0.keys.2.field", "middle"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.with_id", "indexes.#", "2"),
+					resource.TestCheckResourceAttr("data.mongodb_indexes.with_id", "indexes.0.name", "_id_"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &pingDataSource{}
+	_ datasource.DataSourceWithConfigure = &pingDataSource{}
+)
+
+// defaultPingTimeout is the time a ping waits for the server when timeout_seconds is not set.
+const defaultPingTimeout = 5 * time.Second
+
+// pingDataSource is the data source implementation.
+type pingDataSource struct {
+	client *mongodbClient
+}
+
+// pingDataSourceModel maps the data source schema data.
+type pingDataSourceModel struct {
+	TimeoutSeconds *int64       `tfsdk:"timeout_seconds"`
+	FailOnError    *bool        `tfsdk:"fail_on_error"`
+	Reachable      bool         `tfsdk:"reachable"`
+	LatencyMs      int64        `tfsdk:"latency_ms"`
+	Id             types.String `tfsdk:"id"`
+}
+
+// NewPingDataSource is a helper function to simplify the provider implementation.
+func NewPingDataSource() datasource.DataSource {
+	return &pingDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pingDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB ping data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB ping data source")
+}
+
+// Metadata returns the data source type name.
+func (d *pingDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ping"
+}
+
+// Schema defines the schema for the data source.
+func (d *pingDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ping the server to check it is reachable, e.g. to gate dependent resources on connectivity.",
+		Attributes: map[string]schema.Attribute{
+			"timeout_seconds": schema.Int64Attribute{
+				Description: fmt.Sprintf("Time to wait for the server to answer. Defaults to %d seconds.", int64(defaultPingTimeout.Seconds())),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Description: "Whether to fail when the server is not reachable in time, instead of setting reachable to false.",
+				Optional:    true,
+			},
+			"reachable": schema.BoolAttribute{
+				Description: "Whether the server answered in time.",
+				Computed:    true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				Description: "Round trip time of the ping in milliseconds, 0 when the server is not reachable.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pingDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultPingTimeout
+	if state.TimeoutSeconds != nil {
+		timeout = time.Duration(*state.TimeoutSeconds) * time.Second
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Pinging server, timeout %s", timeout))
+
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := d.client.Ping(pingCtx, nil)
+	latency := time.Since(start)
+
+	switch {
+	case err == nil:
+		state.Reachable = true
+		// Round up so a reachable server never reports a zero latency.
+		state.LatencyMs = max(latency.Milliseconds(), 1)
+	case state.FailOnError != nil && *state.FailOnError:
+		resp.Diagnostics.AddError(
+			"Unable to ping server",
+			"An unexpected error occurred when pinging server. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	case mongo.IsTimeout(err) || pingCtx.Err() != nil:
+		tflog.Warn(ctx, fmt.Sprintf("Server not reachable within %s: %v", timeout, err))
+		state.Reachable = false
+		state.LatencyMs = 0
+	default:
+		resp.Diagnostics.AddError(
+			"Unable to ping server",
+			"An unexpected error occurred when pinging server. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.Id = types.StringValue("to_be_ignored")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Pinged server, reachable %t", state.Reachable))
+}
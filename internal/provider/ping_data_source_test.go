@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPingDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_ping" "test" {
+	timeout_seconds = 5
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_ping.test", "reachable", "true"),
+					resource.TestCheckResourceAttrWith("data.mongodb_ping.test", "latency_ms", func(value string) error {
+						latency, err := strconv.ParseInt(value, 10, 64)
+						if err != nil {
+							return err
+						}
+						if latency <= 0 {
+							return fmt.Errorf("expected a positive latency, got %d", latency)
+						}
+						return nil
+					}),
+				),
+			},
+			// Nothing listens on the port, the ping times out and is reported as unreachable.
+			{
+				Config: `
+provider "mongodb" {
+  host = "localhost"
+  port = "1"
+}
+
+data "mongodb_ping" "test" {
+	timeout_seconds = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_ping.test", "reachable", "false"),
+					resource.TestCheckResourceAttr("data.mongodb_ping.test", "latency_ms", "0"),
+				),
+			},
+		},
+	})
+}
@@ -2,30 +2,57 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
+	"net"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/tag"
+	"golang.org/x/sync/semaphore"
+)
+
+const (
+	// providerProbeTimeout bounds the time Configure waits for the server to answer.
+	providerProbeTimeout = 30 * time.Second
+
+	// fallbackProbeTimeout bounds the time Configure waits for each host to answer when fallback hosts are set.
+	fallbackProbeTimeout = 10 * time.Second
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &mongodbProvider{}
+	_ provider.Provider                   = &mongodbProvider{}
+	_ provider.ProviderWithValidateConfig = &mongodbProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
+	providerVersion = version
 	return func() provider.Provider {
 		return &mongodbProvider{
 			version: version,
@@ -42,21 +69,64 @@ type mongodbProvider struct {
 }
 
 type mongodbProviderModel struct {
+	Host                    types.String `tfsdk:"host"`
+	Port                    types.String `tfsdk:"port"`
+	Srv                     types.Bool   `tfsdk:"srv"`
+	CaCertificate           types.String `tfsdk:"ca_certificate"`
+	Certificate             types.String `tfsdk:"certificate"`
+	ClientCertificate       types.String `tfsdk:"client_certificate"`
+	ClientPrivateKey        types.String `tfsdk:"client_private_key"`
+	Username                types.String `tfsdk:"username"`
+	Password                types.String `tfsdk:"password"`
+	AuthMechanism           types.String `tfsdk:"auth_mechanism"`
+	AuthDatabase            types.String `tfsdk:"auth_database"`
+	AuthMechanismProperties types.Map    `tfsdk:"auth_mechanism_properties"`
+	ReplicaSet              types.String `tfsdk:"replica_set"`
+	InsecureSkipVerify      types.Bool   `tfsdk:"insecure_skip_verify"`
+	SSL                     types.Bool   `tfsdk:"ssl"`
+	Direct                  types.Bool   `tfsdk:"direct"`
+	RetryWrites             types.Bool   `tfsdk:"retrywrites"`
+	RetryReads              types.Bool   `tfsdk:"retryreads"`
+	Proxy                   types.String `tfsdk:"proxy"`
+	ProxyCertificate        types.String `tfsdk:"proxy_certificate"`
+	Url                     types.String `tfsdk:"url"`
+	DNSResolver             types.String `tfsdk:"dns_resolver"`
+	StableAPI               types.Bool   `tfsdk:"stable_api"`
+	SessionTag              types.String `tfsdk:"session_tag"`
+	AppName                 types.String `tfsdk:"app_name"`
+	Compressors             types.List   `tfsdk:"compressors"`
+	ZlibLevel               types.Int64  `tfsdk:"zlib_level"`
+	MaxPoolSize             types.Int64  `tfsdk:"max_pool_size"`
+	MinPoolSize             types.Int64  `tfsdk:"min_pool_size"`
+	MaxConnIdleTimeSeconds  types.Int64  `tfsdk:"max_conn_idle_time_seconds"`
+	CausalConsistency       types.Bool   `tfsdk:"causal_consistency"`
+	DefaultCollationLocale  types.String `tfsdk:"default_collation_locale"`
+	StrictDatabase          types.Bool   `tfsdk:"strict_database"`
+	FallbackHosts           types.List   `tfsdk:"fallback_hosts"`
+	Connection              types.Object `tfsdk:"connection"`
+	ReadConcern             types.String `tfsdk:"read_concern"`
+	ReadPreference          types.String `tfsdk:"read_preference"`
+	ReadPreferenceTags      types.List   `tfsdk:"read_preference_tags"`
+	WriteConcern            types.String `tfsdk:"write_concern"`
+	WTimeoutSeconds         types.Int64  `tfsdk:"w_timeout_seconds"`
+	MaxTimeMS               types.Int64  `tfsdk:"max_time_ms"`
+	MaxConcurrentOperations types.Int64  `tfsdk:"max_concurrent_operations"`
+	SkipPing                types.Bool   `tfsdk:"skip_ping"`
+}
+
+// providerConnection maps the connection attribute, which bundles the connection attributes of the same name.
+type providerConnection struct {
 	Host               types.String `tfsdk:"host"`
 	Port               types.String `tfsdk:"port"`
-	CaCertificate      types.String `tfsdk:"ca_certificate"`
-	Certificate        types.String `tfsdk:"certificate"`
 	Username           types.String `tfsdk:"username"`
 	Password           types.String `tfsdk:"password"`
-	AuthMechanism      types.String `tfsdk:"auth_mechanism"`
 	AuthDatabase       types.String `tfsdk:"auth_database"`
+	AuthMechanism      types.String `tfsdk:"auth_mechanism"`
 	ReplicaSet         types.String `tfsdk:"replica_set"`
-	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 	SSL                types.Bool   `tfsdk:"ssl"`
-	Direct             types.Bool   `tfsdk:"direct"`
-	RetryWrites        types.Bool   `tfsdk:"retrywrites"`
-	Proxy              types.String `tfsdk:"proxy"`
-	Url                types.String `tfsdk:"url"`
+	CaCertificate      types.String `tfsdk:"ca_certificate"`
+	Certificate        types.String `tfsdk:"certificate"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 }
 
 // Metadata returns the provider type name.
@@ -71,12 +141,14 @@ func (p *mongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 		Description: "Create resources in MongoDB.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Optional:    true,
-				Description: "The mongodb server address.",
+				Optional: true,
+				Description: "The mongodb server address, or a comma separated list of host:port addresses, e.g. of the members " +
+					"of a replica set, so that the provider connects as long as one of them answers. Port is not set with a list.",
 				Validators: []validator.String{
 					stringvalidator.ExactlyOneOf(
 						path.MatchRoot("host"),
 						path.MatchRoot("url"),
+						path.MatchRoot("connection"),
 					),
 				},
 			},
@@ -84,14 +156,37 @@ func (p *mongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Description: "The mongodb server port",
 			},
+			"srv": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether host is the SRV record name of a cluster, e.g. of MongoDB Atlas, rather than a server address. " +
+					"The hosts, their ports and the default options are looked up in DNS, and TLS is enabled. Conflicts with port.",
+			},
 			"certificate": schema.StringAttribute{
 				Optional:    true,
+				Sensitive:   true,
 				Description: "PEM-encoded content of Mongodb host certificate",
 			},
 			"ca_certificate": schema.StringAttribute{
 				Optional:    true,
 				Description: "PEM-encoded content of Mongodb host CA certificate",
 			},
+			"client_certificate": schema.StringAttribute{
+				Optional: true,
+				Description: "PEM-encoded content of the client certificate presented to servers requiring mutual TLS, e.g. to " +
+					"authenticate with MONGODB-X509. Requires client_private_key. Conflicts with certificate, which bundles both. Ignored with url.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_private_key")),
+					stringvalidator.ConflictsWith(path.MatchRoot("certificate")),
+				},
+			},
+			"client_private_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM-encoded content of the private key of client_certificate. Requires client_certificate.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_certificate")),
+				},
+			},
 			"username": schema.StringAttribute{
 				Optional:    true,
 				Description: "The mongodb user",
@@ -101,13 +196,25 @@ func (p *mongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Description: "The mongodb password",
 			},
 			"auth_mechanism": schema.StringAttribute{
-				Optional:    true,
-				Description: "The mongodb auth mechanism",
+				Optional: true,
+				Description: "The mongodb auth mechanism, one of SCRAM-SHA-1, SCRAM-SHA-256, MONGODB-X509, PLAIN, MONGODB-AWS or GSSAPI. Defaults to the mechanism negotiated with the server. " +
+					"With MONGODB-X509, the client certificate authenticates the user, and username and password can be omitted. " +
+					"With MONGODB-X509 and MONGODB-AWS the auth database is $external, with PLAIN it defaults to $external. " +
+					"With GSSAPI (Kerberos), username is the Kerberos principal, the auth database is $external, and a valid Kerberos ticket must exist in the environment, e.g. obtained with kinit. " +
+					"GSSAPI is only available when the provider is built with the gssapi build tag, which the released binaries are not.",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(authMechanisms...),
+				},
 			},
 			"auth_database": schema.StringAttribute{
 				Optional:    true,
 				Description: "The mongodb auth database",
 			},
+			"auth_mechanism_properties": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Properties of the auth mechanism, e.g. SERVICE_NAME and CANONICALIZE_HOST_NAME for GSSAPI.",
+			},
 			"replica_set": schema.StringAttribute{
 				Optional:    true,
 				Description: "The mongodb replica set",
@@ -122,30 +229,433 @@ func (p *mongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 			},
 			"direct": schema.BoolAttribute{
 				Optional:    true,
-				Description: "enforces a direct connection instead of discovery",
+				Description: "enforces a direct connection instead of discovery. Conflicts with replica_set and mongodb+srv urls. Ignored with url, set directConnection=true in the url instead.",
 			},
 			"retrywrites": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Retryable Writes",
 			},
+			"retryreads": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Retry the reads failing on network errors once, e.g. when refreshing the state over a flaky network. Defaults to true. Ignored with url, set retryReads in the url instead.",
+			},
 			"proxy": schema.StringAttribute{
 				Optional:    true,
 				Description: "Proxy through which to connect to MongoDB. Supported protocols are http, https, and socks5. ",
 			},
+			"proxy_certificate": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM-encoded content of the CA certificate verifying an https proxy. The MongoDB server certificate is still verified against ca_certificate. Defaults to the system CA certificates.",
+			},
+			"dns_resolver": schema.StringAttribute{
+				Optional: true,
+				Description: "IP address, with an optional port defaulting to 53, of the DNS nameserver resolving the SRV and TXT " +
+					"records of a mongodb+srv url, e.g. in split-horizon DNS setups. Defaults to the system resolver.",
+			},
 			"url": schema.StringAttribute{
 				Optional:    true,
-				Description: "The url of the mongodb server.",
+				Description: "The url of the mongodb server. A write concern set in the url replaces the majority write concern used by default on replica sets to create databases, collections and indexes.",
 				Validators: []validator.String{
 					stringvalidator.ExactlyOneOf(
 						path.MatchRoot("host"),
 						path.MatchRoot("url"),
+						path.MatchRoot("connection"),
 					),
 				},
 			},
+			"stable_api": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Use the MongoDB Stable API v1. Defaults to true, but is disabled automatically for servers older than 5.0.",
+			},
+			"session_tag": schema.StringAttribute{
+				Optional:    true,
+				Description: "Run all operations in a single session, started with a ping commented with this tag, to correlate them in the profiler. Operations are serialized when set.",
+			},
+			"app_name": schema.StringAttribute{
+				Optional: true,
+				Description: "Name of the application the connections are made with, visible in db.currentOp() and the server logs " +
+					"to identify the provisioning activity. Overrides the appName of the url. Defaults to terraform-provider-mongodb/<version>.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"compressors": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Compressors of the network traffic, in order of preference, among snappy, zlib and zstd, e.g. to reduce " +
+					"the bandwidth to remote clusters. The server uses the first one it supports. Overrides the compressors of the url.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(compressors...)),
+				},
+			},
+			"zlib_level": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Level of the zlib compression, from -1 for the default level to 9. Requires zlib in compressors.",
+				Validators: []validator.Int64{
+					int64validator.Between(-1, 9),
+				},
+			},
+			"max_pool_size": schema.Int64Attribute{
+				Optional: true,
+				Description: "Maximum number of connections to each server, 0 for no limit, e.g. to not exhaust the connection limit " +
+					"of the server when creating many collections and indexes. Defaults to 100.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_pool_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum number of connections kept open to each server. Defaults to 0.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_conn_idle_time_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time in seconds after which an idle connection is closed. Defaults to no limit.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"strict_database": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Fail to create collections and indexes in databases which do not exist, instead of creating the databases implicitly. Defaults to false.",
+			},
+			"default_collation_locale": schema.StringAttribute{
+				Optional:    true,
+				Description: "Locale of the collation of the collections created without collation, e.g. en. The collation of a collection overrides it.",
+			},
+			"connection": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "The connection attributes as a single object, e.g. a secret decoded from a secrets manager. " +
+					"Conflicts with url and with the attributes it bundles.",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Required:    true,
+						Description: "The mongodb server address.",
+					},
+					"port": schema.StringAttribute{
+						Optional:    true,
+						Description: "The mongodb server port",
+					},
+					"username": schema.StringAttribute{
+						Optional:    true,
+						Description: "The mongodb user",
+					},
+					"password": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The mongodb password",
+					},
+					"auth_database": schema.StringAttribute{
+						Optional:    true,
+						Description: "The mongodb auth database",
+					},
+					"auth_mechanism": schema.StringAttribute{
+						Optional:    true,
+						Description: "The mongodb auth mechanism",
+						Validators: []validator.String{
+							stringvalidator.OneOfCaseInsensitive(authMechanisms...),
+						},
+					},
+					"replica_set": schema.StringAttribute{
+						Optional:    true,
+						Description: "The mongodb replica set",
+					},
+					"ssl": schema.BoolAttribute{
+						Optional:    true,
+						Description: "ssl activation",
+					},
+					"ca_certificate": schema.StringAttribute{
+						Optional:    true,
+						Description: "PEM-encoded content of Mongodb host CA certificate",
+					},
+					"certificate": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "PEM-encoded content of Mongodb host certificate",
+					},
+					"insecure_skip_verify": schema.BoolAttribute{
+						Optional:    true,
+						Description: "ignore hostname verification",
+					},
+				},
+			},
+			"read_concern": schema.StringAttribute{
+				Optional: true,
+				Description: "Read concern of the operations reading resources back, one of local, majority, linearizable or available. " +
+					"With majority, resources are not read in a state which may be rolled back. Defaults to the driver default.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("local", "majority", "linearizable", "available"),
+				},
+			},
+			"read_preference": schema.StringAttribute{
+				Optional: true,
+				Description: "Read preference of the operations reading resources back, e.g. checking that collections and indexes " +
+					"exist, one of primary, primaryPreferred, secondary, secondaryPreferred or nearest. Writes always go to the primary. Defaults to primary.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(readPreferenceModes...),
+				},
+			},
+			"read_preference_tags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+				Description: "Tag sets of the members to read from with read_preference, in order of preference, e.g. " +
+					"[{ region = \"eu\" }, {}]. An empty tag set matches any member. Requires a read_preference other than primary.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.AlsoRequires(path.MatchRoot("read_preference")),
+				},
+			},
+			"write_concern": schema.StringAttribute{
+				Optional: true,
+				Description: "Write concern of all operations, majority, a number of members, 0 for no acknowledgement, or the " +
+					"name of a custom write concern of the replica set. Replaces the write concern of the url and the majority " +
+					"write concern used by default on replica sets to create databases, collections and indexes.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"w_timeout_seconds": schema.Int64Attribute{
+				Optional: true,
+				Description: "Time limit in seconds for the write concern to be satisfied, after which the operations fail " +
+					"even though the writes may still succeed. Requires write_concern. Defaults to no limit.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("write_concern")),
+				},
+			},
+			"max_time_ms": schema.Int64Attribute{
+				Optional: true,
+				Description: "Time limit in milliseconds of the long running commands, i.e. index builds and compact, after which " +
+					"the server aborts them. Defaults to no limit.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_operations": schema.Int64Attribute{
+				Optional: true,
+				Description: "Maximum number of resources created, updated or deleted at the same time, e.g. to not overload " +
+					"a small cluster when Terraform runs many operations in parallel. Defaults to no limit.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"skip_ping": schema.BoolAttribute{
+				Optional: true,
+				Description: "Configure the provider even when the server does not answer, e.g. for a cluster which is not " +
+					"available yet, instead of failing. One of the hosts must still answer when fallback_hosts are set.",
+			},
+			"fallback_hosts": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Hosts to connect to, in order, as host:port, when the server at host does not answer. " +
+					"Each host is given 10 seconds to answer. The other connection options apply to all hosts. Conflicts with url.",
+			},
+			"causal_consistency": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Run all operations in a single causally consistent session, so that data sources observe the writes of the resources applied before them, even when reading from secondaries. Operations are serialized when set. Defaults to true when session_tag is set.",
+			},
 		},
 	}
 }
 
+// ValidateConfig checks the provider configuration for contradictory values.
+func (p *mongodbProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config mongodbProviderModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Connection.IsNull() {
+		conflicting := []struct {
+			name  string
+			value attr.Value
+		}{
+			{"port", config.Port},
+			{"username", config.Username},
+			{"password", config.Password},
+			{"auth_database", config.AuthDatabase},
+			{"auth_mechanism", config.AuthMechanism},
+			{"replica_set", config.ReplicaSet},
+			{"ssl", config.SSL},
+			{"ca_certificate", config.CaCertificate},
+			{"certificate", config.Certificate},
+			{"insecure_skip_verify", config.InsecureSkipVerify},
+		}
+		// host is exclusive with connection through its validators.
+		for _, attribute := range conflicting {
+			if !attribute.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute.name),
+					"Conflicting connection and "+attribute.name,
+					fmt.Sprintf("The connection attribute bundles %s. Please set it either in connection or at the top level, but not both.", attribute.name),
+				)
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(config.applyConnection(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ReplicaSet.ValueString() != "" && config.Direct.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("direct"),
+			"Conflicting replica_set and direct",
+			"A direct connection targets a single server while replica_set discovers the members of the replica set. "+
+				"Please either remove replica_set or set direct to false.",
+		)
+	}
+
+	if strings.EqualFold(config.AuthMechanism.ValueString(), "GSSAPI") && !gssapiSupported {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_mechanism"),
+			"GSSAPI not supported",
+			"This build of the provider does not support GSSAPI (Kerberos) authentication, which requires building it "+
+				"with cgo and the gssapi build tag. The released binaries are built without them. "+
+				"Please either use another auth mechanism or build the provider with: go build -tags gssapi",
+		)
+	}
+
+	if strings.EqualFold(config.AuthMechanism.ValueString(), "MONGODB-X509") && config.Password.ValueString() != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Password with MONGODB-X509",
+			"With the MONGODB-X509 auth mechanism, the client certificate authenticates the user and no password is used. "+
+				"Please remove password.",
+		)
+	}
+
+	if config.Url.ValueString() != "" && len(config.FallbackHosts.Elements()) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fallback_hosts"),
+			"Conflicting url and fallback_hosts",
+			"Fallback hosts apply to a connection configured with host. "+
+				"Please list all the hosts in the url instead, the driver connects to the first available one.",
+		)
+	}
+
+	if !config.ZlibLevel.IsNull() && !config.Compressors.IsUnknown() {
+		var configured []string
+		resp.Diagnostics.Append(config.Compressors.ElementsAs(ctx, &configured, false)...)
+		if !slices.Contains(configured, "zlib") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("zlib_level"),
+				"zlib_level without zlib compressor",
+				"The zlib level only applies to the zlib compression. Please either add zlib to compressors or remove zlib_level.",
+			)
+		}
+	}
+
+	if config.ReadPreference.ValueString() == "primary" && !config.ReadPreferenceTags.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_preference_tags"),
+			"read_preference_tags with primary read preference",
+			"Tag sets select secondaries, there is a single primary. Please either remove read_preference_tags or change read_preference.",
+		)
+	}
+
+	if config.MaxPoolSize.ValueInt64() > 0 && config.MinPoolSize.ValueInt64() > config.MaxPoolSize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_pool_size"),
+			"min_pool_size greater than max_pool_size",
+			fmt.Sprintf("The pool cannot keep %d connections open with at most %d connections. Please lower min_pool_size or raise max_pool_size.",
+				config.MinPoolSize.ValueInt64(), config.MaxPoolSize.ValueInt64()),
+		)
+	}
+
+	if strings.Contains(config.Host.ValueString(), ",") {
+		if _, err := seedList(config.Host.ValueString(), ""); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Invalid host",
+				err.Error(),
+			)
+		}
+		for _, attribute := range []struct {
+			name  string
+			value attr.Value
+		}{
+			{"port", config.Port},
+			{"srv", config.Srv},
+			{"direct", config.Direct},
+			{"fallback_hosts", config.FallbackHosts},
+		} {
+			if !attribute.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute.name),
+					"Conflicting host list and "+attribute.name,
+					fmt.Sprintf("With a list of hosts, each host has its port and the driver connects to any available one. Please remove %s.", attribute.name),
+				)
+			}
+		}
+	}
+
+	if config.Srv.ValueBool() {
+		for _, attribute := range []struct {
+			name  string
+			value attr.Value
+		}{
+			{"port", config.Port},
+			{"fallback_hosts", config.FallbackHosts},
+		} {
+			if !attribute.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute.name),
+					"Conflicting srv and "+attribute.name,
+					fmt.Sprintf("With srv, the hosts and their ports are looked up in DNS. Please remove %s.", attribute.name),
+				)
+			}
+		}
+		if config.Direct.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("direct"),
+				"Conflicting srv and direct",
+				"A direct connection targets a single server while srv resolves the hosts of a cluster. "+
+					"Please either remove srv or set direct to false.",
+			)
+		}
+	}
+
+	if config.DNSResolver.ValueString() != "" {
+		if _, err := parseDNSResolver(config.DNSResolver.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dns_resolver"),
+				"Invalid dns_resolver",
+				"The DNS nameserver address is invalid: "+err.Error(),
+			)
+		} else if !config.Url.IsUnknown() && !config.Srv.IsUnknown() && !config.Srv.ValueBool() && !strings.HasPrefix(config.Url.ValueString(), "mongodb+srv://") {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("dns_resolver"),
+				"dns_resolver ignored without SRV url",
+				"The DNS nameserver only resolves the records of a mongodb+srv url or of host with srv. Please remove dns_resolver.",
+			)
+		}
+	}
+
+	if strings.HasPrefix(config.Url.ValueString(), "mongodb+srv://") && config.Direct.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("direct"),
+			"Conflicting SRV url and direct",
+			"A direct connection targets a single server while a mongodb+srv url resolves the hosts of a cluster. "+
+				"Please either use a mongodb:// url or set direct to false.",
+		)
+	} else if config.Url.ValueString() != "" && config.Direct.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("direct"),
+			"direct ignored with url",
+			"The connection options of a url are taken from the url only. "+
+				"Please set directConnection=true in the url for a direct connection, and remove direct.",
+		)
+	}
+}
+
 func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	tflog.Info(ctx, "Configuring MongoDB provider")
 
@@ -157,6 +667,11 @@ func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	resp.Diagnostics.Append(config.applyConnection(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 	if config.Url.ValueString() == "" && config.Host.ValueString() == "" {
@@ -182,41 +697,335 @@ func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
+	// Create a new client using the configuration values
+	tflog.Info(ctx, "Creating MongoDB client")
+
+	// The server is probed once, within a bounded time, so that an unreachable server fails the configuration
+	// rather than later operations. The result decides the stable API and the write concern of creations.
+	opts, client, server, diags := connectWithFallback(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The probe runs without the stable API so that legacy servers, which don't support it, can still be
+	// used. The client only needs to be recreated when the stable API is relevant; connecting is lazy, so
+	// it does not talk to the server again here.
+	if server != nil && server.MaxWireVersion < stableAPIWireVersion && (config.StableAPI.IsNull() || config.StableAPI.ValueBool()) {
+		tflog.Warn(ctx, fmt.Sprintf("MongoDB server wire version %d is older than 5.0, stable API is disabled", server.MaxWireVersion))
+	}
+	if serverAPI := stableAPIOptions(config.StableAPI, server); serverAPI != nil {
+		_ = client.Disconnect(ctx)
+		var err error
+		client, err = mongo.Connect(context.TODO(), opts.SetServerAPIOptions(serverAPI))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create MongoDB Client",
+				"An unexpected error occurred when creating the MongoDB client. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	providerClient := &mongodbClient{
+		Client:                 client,
+		connectionString:       effectiveConnectionURI(opts),
+		stableAPI:              opts.ServerAPIOptions != nil,
+		defaultCollationLocale: config.DefaultCollationLocale.ValueString(),
+		strictDatabase:         config.StrictDatabase.ValueBool(),
+		maxTime:                time.Duration(config.MaxTimeMS.ValueInt64()) * time.Millisecond,
+		authDatabase:           config.AuthDatabase.ValueString(),
+	}
+	if config.MaxConcurrentOperations.ValueInt64() > 0 {
+		providerClient.operations = semaphore.NewWeighted(config.MaxConcurrentOperations.ValueInt64())
+	}
+	trackClient(providerClient)
+
+	if config.ReadConcern.ValueString() != "" {
+		providerClient.readConcern = &readconcern.ReadConcern{Level: config.ReadConcern.ValueString()}
+	}
+
+	if config.ReadPreference.ValueString() != "" {
+		var tagSets []map[string]string
+		resp.Diagnostics.Append(config.ReadPreferenceTags.ElementsAs(ctx, &tagSets, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		preference, err := parseReadPreference(config.ReadPreference.ValueString(), tagSets)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_preference"),
+				"Invalid read preference",
+				err.Error(),
+			)
+			return
+		}
+		providerClient.readPreference = preference
+	}
+
+	// On replica sets, creations are acknowledged by a majority so that the following reads of the apply
+	// observe them, even from another member. A write concern set in the url is kept as is.
+	if opts.WriteConcern == nil && server != nil && server.SetName != "" {
+		providerClient.ddlWriteConcern = writeconcern.Majority()
+	}
+
+	causalConsistency := config.CausalConsistency.ValueBool() || (config.CausalConsistency.IsNull() && config.SessionTag.ValueString() != "")
+	if config.SessionTag.ValueString() != "" || causalConsistency {
+		sessionCtx, cancel := context.WithTimeout(ctx, providerProbeTimeout)
+		defer cancel()
+
+		err := providerClient.startSession(sessionCtx, config.SessionTag.ValueString(), causalConsistency)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to start MongoDB session",
+				"An unexpected error occurred when starting the session. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Operations run in a session tagged %q, causal consistency: %t", config.SessionTag.ValueString(), causalConsistency))
+	}
+
+	// Make the client available during DataSource and Resource type Configure methods.
+	resp.DataSourceData = providerClient
+	resp.ResourceData = providerClient
+
+	tflog.Info(ctx, "Configured MongoDB provider")
+}
+
+// applyConnection copies the attributes of the connection attribute, when set, to the attributes they stand for.
+func (m *mongodbProviderModel) applyConnection(ctx context.Context) diag.Diagnostics {
+	if m.Connection.IsNull() || m.Connection.IsUnknown() {
+		return nil
+	}
+
+	var connection providerConnection
+	diags := m.Connection.As(ctx, &connection, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return diags
+	}
+
+	m.Host = connection.Host
+	m.Port = connection.Port
+	m.Username = connection.Username
+	m.Password = connection.Password
+	m.AuthDatabase = connection.AuthDatabase
+	m.AuthMechanism = connection.AuthMechanism
+	m.ReplicaSet = connection.ReplicaSet
+	m.SSL = connection.SSL
+	m.CaCertificate = connection.CaCertificate
+	m.Certificate = connection.Certificate
+	m.InsecureSkipVerify = connection.InsecureSkipVerify
+	return diags
+}
+
+// connectWithFallback connects to the configured server and probes it. When it does not answer and fallback hosts
+// are set, each fallback host is tried in turn, and the hosts tried are reported. Without fallback hosts, a server
+// which does not answer is an error, unless skip_ping is set, in which case it is only logged, the client
+// connecting lazily.
+func connectWithFallback(ctx context.Context, config mongodbProviderModel) (*options.ClientOptions, *mongo.Client, *serverInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var fallbackHosts []string
+	if !config.FallbackHosts.IsNull() && !config.FallbackHosts.IsUnknown() {
+		diags.Append(config.FallbackHosts.ElementsAs(ctx, &fallbackHosts, false)...)
+		if diags.HasError() {
+			return nil, nil, nil, diags
+		}
+	}
+
+	candidates := []mongodbProviderModel{config}
+	for _, fallbackHost := range fallbackHosts {
+		host, port, err := net.SplitHostPort(fallbackHost)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("fallback_hosts"),
+				"Invalid fallback host",
+				fmt.Sprintf("Fallback host %q must be formatted as host:port.\n\nError: %s", fallbackHost, err.Error()),
+			)
+			return nil, nil, nil, diags
+		}
+		candidate := config
+		candidate.Host = types.StringValue(host)
+		candidate.Port = types.StringValue(port)
+		candidates = append(candidates, candidate)
+	}
+
+	probeTimeout := providerProbeTimeout
+	if len(candidates) > 1 {
+		probeTimeout = fallbackProbeTimeout
+	}
+
+	var failures []string
+	for i, candidate := range candidates {
+		opts, optsDiags := providerClientOptions(ctx, candidate)
+		diags.Append(optsDiags...)
+		if diags.HasError() {
+			return nil, nil, nil, diags
+		}
+
+		client, err := mongo.Connect(context.TODO(), opts)
+		if err != nil {
+			diags.AddError(
+				"Unable to Create MongoDB Client",
+				"An unexpected error occurred when creating the MongoDB client. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return nil, nil, nil, diags
+		}
+
+		probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+		server, err := probeServer(probeCtx, client)
+		cancel()
+
+		if err != nil && len(candidates) == 1 && !config.SkipPing.ValueBool() {
+			_ = client.Disconnect(ctx)
+			diags.AddError(
+				"Unable to connect to MongoDB",
+				fmt.Sprintf("The server at %s did not answer within %s. Set skip_ping to configure the provider "+
+					"while the server is not available yet.\n\nError: %s", effectiveConnectionURI(opts), probeTimeout, err.Error()),
+			)
+			return nil, nil, nil, diags
+		}
+
+		if err == nil || len(candidates) == 1 {
+			if err != nil {
+				tflog.Warn(ctx, "Unable to describe MongoDB server, continuing as skip_ping is set: "+err.Error())
+				server = nil
+			}
+			if len(failures) > 0 {
+				diags.AddWarning(
+					"Connected to a fallback host",
+					fmt.Sprintf("Connected to %s:%s after the following hosts did not answer:\n%s",
+						candidate.Host.ValueString(), candidate.Port.ValueString(), strings.Join(failures, "\n")),
+				)
+			}
+			return opts, client, server, diags
+		}
+
+		tflog.Warn(ctx, fmt.Sprintf("MongoDB host %s:%s did not answer (%d/%d): %s",
+			candidate.Host.ValueString(), candidate.Port.ValueString(), i+1, len(candidates), err.Error()))
+		failures = append(failures, fmt.Sprintf("- %s:%s: %s", candidate.Host.ValueString(), candidate.Port.ValueString(), err.Error()))
+		_ = client.Disconnect(ctx)
+	}
+
+	diags.AddError(
+		"Unable to connect to MongoDB",
+		"None of the host and fallback hosts answered:\n"+strings.Join(failures, "\n"),
+	)
+	return nil, nil, nil, diags
+}
+
+// seedList returns the hosts of the connection URI of host and port. host is either a single address, using port,
+// or a comma separated list of host:port addresses.
+func seedList(host string, port string) (string, error) {
+	if !strings.Contains(host, ",") {
+		return host + ":" + port, nil
+	}
+
+	hosts := strings.Split(host, ",")
+	for i, address := range hosts {
+		address = strings.TrimSpace(address)
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return "", fmt.Errorf("host %q of the list must be formatted as host:port", address)
+		}
+		hosts[i] = address
+	}
+	return strings.Join(hosts, ","), nil
+}
+
+// compressors are the network compressors supported by the driver.
+var compressors = []string{"snappy", "zlib", "zstd"}
+
+// authMechanisms are the auth mechanisms supported by the provider.
+var authMechanisms = []string{"SCRAM-SHA-1", "SCRAM-SHA-256", "MONGODB-X509", "PLAIN", "MONGODB-AWS", "GSSAPI"}
+
+// providerClientOptions builds the client options of the provider configuration.
+func providerClientOptions(ctx context.Context, config mongodbProviderModel) (*options.ClientOptions, diag.Diagnostics) {
 	var opts *options.ClientOptions
+	var diags diag.Diagnostics
 	if config.Url.ValueString() != "" {
 		uri := config.Url.ValueString()
-		opts = options.Client().ApplyURI(uri).SetServerAPIOptions(serverAPI)
+		if config.DNSResolver.ValueString() != "" && strings.HasPrefix(uri, "mongodb+srv://") {
+			resolved, err := resolveWithDNSResolver(ctx, config.DNSResolver.ValueString(), uri)
+			if err != nil {
+				diags.AddError(
+					"Unable to resolve SRV url",
+					"An unexpected error occurred when resolving the SRV url with dns_resolver. "+
+						reportFooter()+
+						"Error: "+err.Error(),
+				)
+				return nil, diags
+			}
+			uri = resolved
+		}
+		tflog.Debug(ctx, "Connecting with url "+redactConnectionURI(uri, ""))
+		opts = options.Client().ApplyURI(uri)
 
 	} else {
 		var arguments = ""
 
 		arguments = addArgs(arguments, "retrywrites="+strconv.FormatBool(config.RetryWrites.ValueBool()))
 
+		if !config.RetryReads.IsNull() {
+			arguments = addArgs(arguments, "retryReads="+strconv.FormatBool(config.RetryReads.ValueBool()))
+		}
+
 		if config.SSL.ValueBool() {
 			arguments = addArgs(arguments, "ssl=true")
 		}
 
-		if config.ReplicaSet.ValueString() != "" && !config.Direct.ValueBool() {
+		if config.ReplicaSet.ValueString() != "" {
 			arguments = addArgs(arguments, "replicaSet="+config.ReplicaSet.ValueString())
 		}
 
-		if config.Direct.ValueBool() {
-			arguments = addArgs(arguments, "connect="+"direct")
+		if config.AppName.ValueString() != "" {
+			arguments = addArgs(arguments, "appName="+url.QueryEscape(config.AppName.ValueString()))
+		}
+
+		hosts, err := seedList(config.Host.ValueString(), config.Port.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("host"),
+				"Invalid host",
+				err.Error(),
+			)
+			return nil, diags
 		}
 
-		uri := "mongodb://" + config.Host.ValueString() + ":" + config.Port.ValueString() + arguments
+		uri := "mongodb://" + hosts + arguments
+		if config.Srv.ValueBool() {
+			// SRV connection strings have no port, the SRV records give the port of each host.
+			uri = "mongodb+srv://" + config.Host.ValueString() + arguments
+			if config.DNSResolver.ValueString() != "" {
+				resolved, err := resolveWithDNSResolver(ctx, config.DNSResolver.ValueString(), uri)
+				if err != nil {
+					diags.AddError(
+						"Unable to resolve SRV host",
+						"An unexpected error occurred when resolving the SRV host with dns_resolver. "+
+							reportFooter()+
+							"Error: "+err.Error(),
+					)
+					return nil, diags
+				}
+				uri = resolved
+			}
+		}
+		tflog.Debug(ctx, "Connecting with uri "+redactConnectionURI(uri, config.Username.ValueString()))
 
-		dialer, dialerErr := proxyDialer(config.Proxy.ValueString())
+		dialer, dialerErr := proxyDialer(config.Proxy.ValueString(), []byte(config.ProxyCertificate.ValueString()))
 
 		if dialerErr != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Unable to create proxy dialer",
 				"An unexpected error occurred when creating the proxy dialer. "+
-					"If the error is not clear, please contact the provider developers.\n\n"+
+					reportFooter()+
 					"Error: "+dialerErr.Error(),
 			)
-			return
+			return nil, diags
 		}
 
 		var verify = false
@@ -225,52 +1034,177 @@ func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureR
 			verify = true
 		}
 
-		if config.Certificate.ValueString() != "" {
-			tlsConfig, err := getTLSConfigWithAllServerCertificates([]byte(config.CaCertificate.ValueString()), []byte(config.Certificate.ValueString()), []byte(config.Certificate.ValueString()), verify)
+		credential, credentialDiags := providerCredential(ctx, config)
+		diags.Append(credentialDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// certificate bundles the client certificate and its private key in a single PEM.
+		certPEM, keyPEM := config.Certificate.ValueString(), config.Certificate.ValueString()
+		if config.ClientCertificate.ValueString() != "" {
+			certPEM, keyPEM = config.ClientCertificate.ValueString(), config.ClientPrivateKey.ValueString()
+		}
+
+		if certPEM != "" {
+			tlsConfig, err := getTLSConfigWithAllServerCertificates([]byte(config.CaCertificate.ValueString()), []byte(certPEM), []byte(keyPEM), verify)
 			if err != nil {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Unable to read certificate",
 					"An unexpected error occurred when reading the certificate. "+
-						"If the error is not clear, please contact the provider developers.\n\n"+
+						reportFooter()+
 						"Error: "+err.Error(),
 				)
-				return
+				return nil, diags
 			}
 
-			opts = options.Client().ApplyURI(uri).SetServerAPIOptions(serverAPI).SetAuth(options.Credential{
-				AuthSource: config.AuthDatabase.ValueString(), Username: config.Username.ValueString(), Password: config.Password.ValueString(), AuthMechanism: config.AuthMechanism.ValueString(),
-			}).SetTLSConfig(tlsConfig).SetDialer(dialer)
+			opts = options.Client().ApplyURI(uri).SetAuth(credential).SetTLSConfig(tlsConfig).SetDialer(dialer)
 
 		} else {
-			opts = options.Client().ApplyURI(uri).SetServerAPIOptions(serverAPI).SetAuth(options.Credential{
-				AuthSource: config.AuthDatabase.ValueString(), Username: config.Username.ValueString(), Password: config.Password.ValueString(), AuthMechanism: config.AuthMechanism.ValueString(),
-			}).SetDialer(dialer)
+			opts = options.Client().ApplyURI(uri).SetAuth(credential).SetDialer(dialer)
+		}
+
+		if config.Direct.ValueBool() {
+			opts.SetDirect(true)
 		}
 	}
-	// Create a new client using the configuration values
-	tflog.Info(ctx, "Creating MongoDB client")
 
-	client, err := mongo.Connect(context.TODO(), opts)
+	if config.WriteConcern.ValueString() != "" {
+		concern, err := parseWriteConcern(config.WriteConcern.ValueString(), config.WTimeoutSeconds.ValueInt64())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("write_concern"),
+				"Invalid write concern",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		opts.SetWriteConcern(concern)
+	}
+
+	if !config.Compressors.IsNull() && !config.Compressors.IsUnknown() {
+		var configured []string
+		diags.Append(config.Compressors.ElementsAs(ctx, &configured, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		opts.SetCompressors(configured)
+	}
+	if !config.ZlibLevel.IsNull() {
+		opts.SetZlibLevel(int(config.ZlibLevel.ValueInt64()))
+	}
+
+	if !config.MaxPoolSize.IsNull() {
+		opts.SetMaxPoolSize(uint64(config.MaxPoolSize.ValueInt64()))
+	}
+	if !config.MinPoolSize.IsNull() {
+		opts.SetMinPoolSize(uint64(config.MinPoolSize.ValueInt64()))
+	}
+	if !config.MaxConnIdleTimeSeconds.IsNull() {
+		opts.SetMaxConnIdleTime(time.Duration(config.MaxConnIdleTimeSeconds.ValueInt64()) * time.Second)
+	}
+
+	if config.AppName.ValueString() != "" {
+		opts.SetAppName(config.AppName.ValueString())
+	} else if opts.AppName == nil {
+		opts.SetAppName(defaultAppName())
+	}
+
+	return opts, diags
+}
+
+// parseWriteConcern parses the write_concern attribute, a number of members or the name of a write concern,
+// with the time limit in seconds, 0 for no limit.
+func parseWriteConcern(value string, timeoutSeconds int64) (*writeconcern.WriteConcern, error) {
+	concern := &writeconcern.WriteConcern{W: value}
+	if members, err := strconv.Atoi(value); err == nil {
+		if members < 0 {
+			return nil, fmt.Errorf("expected a positive number of members, got %d", members)
+		}
+		concern.W = members
+	}
+	if timeoutSeconds > 0 {
+		if concern.W == 0 {
+			return nil, errors.New("w_timeout_seconds cannot be set with an unacknowledged write concern")
+		}
+		concern.WTimeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	return concern, nil
+}
+
+// readPreferenceModes are the read preferences supported by the provider.
+var readPreferenceModes = []string{"primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest"}
+
+// parseReadPreference parses the read_preference attribute with the tag sets of read_preference_tags.
+func parseReadPreference(mode string, tagSets []map[string]string) (*readpref.ReadPref, error) {
+	parsed, err := readpref.ModeFromString(mode)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create MongoDB Client",
-			"An unexpected error occurred when creating the MongoDB client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Error: "+err.Error(),
-		)
-		return
+		return nil, err
+	}
+	if len(tagSets) == 0 {
+		return readpref.New(parsed)
+	}
+	sets := make([]tag.Set, 0, len(tagSets))
+	for _, tags := range tagSets {
+		sets = append(sets, tag.NewTagSetFromMap(tags))
+	}
+	return readpref.New(parsed, readpref.WithTagSets(sets...))
+}
+
+// providerCredential builds the credential used to authenticate connections configured with host.
+func providerCredential(ctx context.Context, config mongodbProviderModel) (options.Credential, diag.Diagnostics) {
+	credential := options.Credential{
+		AuthSource:    config.AuthDatabase.ValueString(),
+		Username:      config.Username.ValueString(),
+		Password:      config.Password.ValueString(),
+		AuthMechanism: config.AuthMechanism.ValueString(),
 	}
 
-	// Make the client available during DataSource and Resource type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	var diags diag.Diagnostics
+	if !config.AuthMechanismProperties.IsNull() {
+		diags.Append(config.AuthMechanismProperties.ElementsAs(ctx, &credential.AuthMechanismProperties, false)...)
+	}
 
-	tflog.Info(ctx, "Configured MongoDB provider")
+	credential.AuthMechanism = strings.ToUpper(credential.AuthMechanism)
+	switch credential.AuthMechanism {
+	case "GSSAPI":
+		// Kerberos principals are authenticated by the $external database, with a ticket rather than a password.
+		credential.AuthSource = "$external"
+		credential.PasswordSet = credential.Password != ""
+	case "MONGODB-X509", "MONGODB-AWS":
+		// Authenticated by the $external database with the client certificate or the AWS credentials, the
+		// username being optional.
+		credential.AuthSource = "$external"
+	case "PLAIN":
+		// LDAP users are usually defined in the $external database.
+		if credential.AuthSource == "" {
+			credential.AuthSource = "$external"
+		}
+	}
+
+	return credential, diags
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *mongodbProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewCurrentUserDataSource,
+		NewQueryPlanDataSource,
+		NewChunkDistributionDataSource,
+		NewConnectionStringDataSource,
+		NewSrvHostsDataSource,
+		NewDatabasesDataSource,
+		NewIndexesDataSource,
+		NewIndexDataSource,
+		NewAuthStatusDataSource,
+		NewTTLMonitorDataSource,
+		NewStorageStatsDataSource,
+		NewPingDataSource,
+		NewIndexBuildsDataSource,
+		NewCollectionDataSource,
+		NewShardKeyDataSource,
+		NewDatabaseDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
@@ -279,5 +1213,10 @@ func (p *mongodbProvider) Resources(_ context.Context) []func() resource.Resourc
 		NewIndexResource,
 		NewDatabaseResource,
 		NewCollectionResource,
+		NewCollectionCompactResource,
+		NewViewResource,
+		NewCollectionIndexResource,
+		NewUserResource,
+		NewRoleResource,
 	}
 }
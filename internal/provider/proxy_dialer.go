@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// httpConnectDialer dials addresses through an http or https proxy, with the HTTP CONNECT method.
+type httpConnectDialer struct {
+	proxyURL *url.URL
+
+	// tlsConfig verifies the proxy certificate, nil for http proxies.
+	tlsConfig *tls.Config
+}
+
+// newHTTPConnectDialer creates a dialer through the proxy at proxyURL. The certificate of https proxies is
+// verified against the CA certificates in proxyCaPEM, or the system ones when empty.
+func newHTTPConnectDialer(proxyURL *url.URL, proxyCaPEM []byte) (*httpConnectDialer, error) {
+	dialer := &httpConnectDialer{proxyURL: proxyURL}
+	if proxyURL.Scheme == "https" {
+		tlsConfig, err := getTLSConfigWithAllServerCertificates(proxyCaPEM, nil, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ServerName = proxyURL.Hostname()
+		dialer.tlsConfig = tlsConfig
+	}
+	return dialer, nil
+}
+
+// DialContext connects to the proxy and asks it to tunnel the connection to address.
+func (d *httpConnectDialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	proxyAddress := d.proxyURL.Host
+	if d.proxyURL.Port() == "" {
+		port := "80"
+		if d.tlsConfig != nil {
+			port = "443"
+		}
+		proxyAddress = net.JoinHostPort(d.proxyURL.Hostname(), port)
+	}
+
+	var netDialer net.Dialer
+	conn, err := netDialer.DialContext(ctx, "tcp", proxyAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Time{})
+	}
+
+	if d.tlsConfig != nil {
+		tlsConn := tls.Client(conn, d.tlsConfig)
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("unable to verify proxy certificate: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if user := d.proxyURL.User; user != nil {
+		password, _ := user.Password()
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user.Username()+":"+password)))
+	}
+	if err = req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// The body of a successful response is the tunnel itself, it must not be read.
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused to connect to %s: %s", address, resp.Status)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	// The server does not talk first, but keep anything the proxy may have sent along with its response.
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a connection whose first bytes were already read in a buffer.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// testSelfSignedCertificate generates a certificate for 127.0.0.1, acting as its own CA.
+func testSelfSignedCertificate(t *testing.T) (tls.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "backend"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Unable to create certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// testConnectProxy starts an https proxy tunneling CONNECT requests.
+func testConnectProxy(t *testing.T) *httptest.Server {
+	proxy := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "only CONNECT is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		backend, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		client, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			backend.Close()
+			return
+		}
+		go func() {
+			_, _ = io.Copy(backend, client)
+			backend.Close()
+		}()
+		_, _ = io.Copy(client, backend)
+		client.Close()
+	}))
+	t.Cleanup(proxy.Close)
+	return proxy
+}
+
+func TestHTTPConnectDialerDistinctCAs(t *testing.T) {
+	backendCertificate, backendCaPEM := testSelfSignedCertificate(t)
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "backend")
+	}))
+	backend.TLS = &tls.Config{Certificates: []tls.Certificate{backendCertificate}}
+	backend.StartTLS()
+	t.Cleanup(backend.Close)
+
+	proxy := testConnectProxy(t)
+	proxyCaPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: proxy.Certificate().Raw})
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	// The proxy is verified against its own CA, the backend against the main one.
+	dialer, err := proxyDialer(proxyURL.String(), proxyCaPEM)
+	if err != nil {
+		t.Fatalf("Unable to create dialer: %v", err)
+	}
+	backendTLSConfig, err := getTLSConfigWithAllServerCertificates(backendCaPEM, nil, nil, false)
+	if err != nil {
+		t.Fatalf("Unable to create backend TLS config: %v", err)
+	}
+	client := &http.Client{Transport: &http.Transport{
+		DialContext:     dialer.DialContext,
+		TLSClientConfig: backendTLSConfig,
+	}}
+	resp, err := client.Get(backend.URL)
+	if err != nil {
+		t.Fatalf("Unable to reach backend through proxy: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "backend" {
+		t.Errorf("Expected backend response, got %q", body)
+	}
+
+	// The backend CA does not verify the proxy.
+	dialer, err = proxyDialer(proxyURL.String(), backendCaPEM)
+	if err != nil {
+		t.Fatalf("Unable to create dialer: %v", err)
+	}
+	_, err = dialer.DialContext(context.Background(), "tcp", backend.Listener.Addr().String())
+	if err == nil {
+		t.Errorf("Expected the proxy certificate to be rejected with the backend CA")
+	}
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &queryPlanDataSource{}
+	_ datasource.DataSourceWithConfigure = &queryPlanDataSource{}
+)
+
+// queryPlanDataSource is the data source implementation.
+type queryPlanDataSource struct {
+	client *mongodbClient
+}
+
+// queryPlanDataSourceModel maps the data source schema data.
+type queryPlanDataSourceModel struct {
+	Database         string       `tfsdk:"database"`
+	Collection       string       `tfsdk:"collection"`
+	Query            string       `tfsdk:"query"`
+	Sort             *string      `tfsdk:"sort"`
+	WinningPlanStage types.String `tfsdk:"winning_plan_stage"`
+	IndexName        types.String `tfsdk:"index_name"`
+	IsCollscan       types.Bool   `tfsdk:"is_collscan"`
+	Id               types.String `tfsdk:"id"`
+}
+
+// NewQueryPlanDataSource is a helper function to simplify the provider implementation.
+func NewQueryPlanDataSource() datasource.DataSource {
+	return &queryPlanDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *queryPlanDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB query plan data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB query plan data source")
+}
+
+// Metadata returns the data source type name.
+func (d *queryPlanDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_plan"
+}
+
+// Schema defines the schema for the data source.
+func (d *queryPlanDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Explain a query to check which index, if any, serves it.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database to query.",
+				Required:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "Name of the collection to query.",
+				Required:    true,
+			},
+			"query": schema.StringAttribute{
+				Description: "Query filter, as extended JSON.",
+				Required:    true,
+			},
+			"sort": schema.StringAttribute{
+				Description: "Sort specification, as extended JSON.",
+				Optional:    true,
+			},
+			"winning_plan_stage": schema.StringAttribute{
+				Description: "Top stage of the winning plan, e.g. FETCH or COLLSCAN.",
+				Computed:    true,
+			},
+			"index_name": schema.StringAttribute{
+				Description: "Name of the index used by the winning plan, if any.",
+				Computed:    true,
+			},
+			"is_collscan": schema.BoolAttribute{
+				Description: "Whether the winning plan scans the whole collection.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *queryPlanDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state queryPlanDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database
+	collectionName := state.Collection
+
+	tflog.Debug(ctx, fmt.Sprintf("Explaining query on %s.%s", databaseName, collectionName))
+
+	var filter bson.D
+	err := bson.UnmarshalExtJSON([]byte(state.Query), false, &filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse query",
+			"The query must be a valid extended JSON document.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	find := bson.D{
+		{Key: "find", Value: collectionName},
+		{Key: "filter", Value: filter},
+	}
+	if state.Sort != nil {
+		var sort bson.D
+		err = bson.UnmarshalExtJSON([]byte(*state.Sort), false, &sort)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to parse sort",
+				"The sort must be a valid extended JSON document.\n\n"+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+		find = append(find, bson.E{Key: "sort", Value: sort})
+	}
+
+	var explain struct {
+		QueryPlanner struct {
+			WinningPlan bson.M `bson:"winningPlan"`
+		} `bson:"queryPlanner"`
+	}
+	err = d.client.Database(databaseName).RunCommand(ctx, bson.D{
+		{Key: "explain", Value: find},
+		{Key: "verbosity", Value: "queryPlanner"},
+	}).Decode(&explain)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to explain query",
+			"An unexpected error occurred when explaining query. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	topStage, scanStage, indexName := parseWinningPlan(explain.QueryPlanner.WinningPlan)
+
+	state.WinningPlanStage = types.StringValue(topStage)
+	if indexName != "" {
+		state.IndexName = types.StringValue(indexName)
+	} else {
+		state.IndexName = types.StringNull()
+	}
+	state.IsCollscan = types.BoolValue(scanStage == "COLLSCAN")
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, collectionName))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Explained query on %s.%s", databaseName, collectionName))
+}
+
+// Get the top stage of a winning plan, and the stage and index name of the scan it is built on.
+func parseWinningPlan(plan bson.M) (string, string, string) {
+	// Plans of the slot based execution engine are wrapped in a queryPlan document.
+	if queryPlan, ok := plan["queryPlan"].(bson.M); ok {
+		plan = queryPlan
+	}
+
+	topStage, _ := plan["stage"].(string)
+	scanStage, indexName := findScanStage(plan)
+	return topStage, scanStage, indexName
+}
+
+func findScanStage(plan bson.M) (string, string) {
+	stage, _ := plan["stage"].(string)
+	switch stage {
+	case "COLLSCAN":
+		return stage, ""
+	case "IXSCAN", "EXPRESS_IXSCAN":
+		indexName, _ := plan["indexName"].(string)
+		return stage, indexName
+	}
+
+	if inputStage, ok := plan["inputStage"].(bson.M); ok {
+		return findScanStage(inputStage)
+	}
+	if inputStages, ok := plan["inputStages"].(bson.A); ok {
+		for _, input := range inputStages {
+			if inputStage, ok := input.(bson.M); ok {
+				if scanStage, indexName := findScanStage(inputStage); scanStage != "" {
+					return scanStage, indexName
+				}
+			}
+		}
+	}
+
+	return stage, ""
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAccQueryPlanDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_index" "query_plan" {
+  database   = "test"
+  collection = "test_query_plan"
+  name       = "field1_asc"
+  keys = [
+    {
+      "field" : "field1"
+      "type" : "asc"
+    }
+  ]
+}
+
+data "mongodb_query_plan" "indexed" {
+  database   = mongodb_index.query_plan.database
+  collection = mongodb_index.query_plan.collection
+  query      = "{\"field1\": \"value\"}"
+}
+
+data "mongodb_query_plan" "not_indexed" {
+  database   = mongodb_index.query_plan.database
+  collection = mongodb_index.query_plan.collection
+  query      = "{\"field2\": \"value\"}"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_query_plan.indexed", "is_collscan", "false"),
+					resource.TestCheckResourceAttr("data.mongodb_query_plan.indexed", "index_name", "field1_asc"),
+					resource.TestCheckResourceAttr("data.mongodb_query_plan.not_indexed", "is_collscan", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_query_plan.not_indexed", "winning_plan_stage", "COLLSCAN"),
+					resource.TestCheckNoResourceAttr("data.mongodb_query_plan.not_indexed", "index_name"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseWinningPlanIxscan(t *testing.T) {
+	plan := bson.M{
+		"stage": "FETCH",
+		"inputStage": bson.M{
+			"stage":     "IXSCAN",
+			"indexName": "field1_asc",
+		},
+	}
+
+	topStage, scanStage, indexName := parseWinningPlan(plan)
+	if topStage != "FETCH" || scanStage != "IXSCAN" || indexName != "field1_asc" {
+		t.Fatalf("Expected FETCH, IXSCAN, field1_asc, got %v, %v, %v", topStage, scanStage, indexName)
+	}
+}
+
+func TestParseWinningPlanSbeCollscan(t *testing.T) {
+	plan := bson.M{
+		"queryPlan": bson.M{
+			"stage": "COLLSCAN",
+		},
+	}
+
+	topStage, scanStage, indexName := parseWinningPlan(plan)
+	if topStage != "COLLSCAN" || scanStage != "COLLSCAN" || indexName != "" {
+		t.Fatalf("Expected COLLSCAN, COLLSCAN, empty index, got %v, %v, %v", topStage, scanStage, indexName)
+	}
+}
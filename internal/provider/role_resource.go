@@ -0,0 +1,583 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &roleResource{}
+	_ resource.ResourceWithConfigure   = &roleResource{}
+	_ resource.ResourceWithImportState = &roleResource{}
+	_ resource.ResourceWithModifyPlan  = &roleResource{}
+)
+
+// roleResource is the resource implementation.
+type roleResource struct {
+	client *mongodbClient
+}
+
+// roleResourceModel maps the resource schema data.
+type roleResourceModel struct {
+	Database                   types.String                `tfsdk:"database"`
+	RoleName                   string                      `tfsdk:"role_name"`
+	Privileges                 []rolePrivilege             `tfsdk:"privileges"`
+	Roles                      []userRole                  `tfsdk:"roles"`
+	AuthenticationRestrictions []authenticationRestriction `tfsdk:"authentication_restrictions"`
+	Id                         types.String                `tfsdk:"id"`
+}
+
+// rolePrivilege maps a privilege of the role.
+type rolePrivilege struct {
+	Resource rolePrivilegeResource `tfsdk:"resource"`
+	Actions  []string              `tfsdk:"actions"`
+}
+
+// rolePrivilegeResource maps the resource a privilege grants its actions on.
+type rolePrivilegeResource struct {
+	Db         types.String `tfsdk:"db"`
+	Collection types.String `tfsdk:"collection"`
+	Cluster    types.Bool   `tfsdk:"cluster"`
+}
+
+// roleInfo maps the roles returned by rolesInfo.
+type roleInfo struct {
+	Role                       string        `bson:"role"`
+	Db                         string        `bson:"db"`
+	Privileges                 []privilege   `bson:"privileges"`
+	Roles                      []userRole    `bson:"roles"`
+	AuthenticationRestrictions bson.RawValue `bson:"authenticationRestrictions"`
+}
+
+// NewRoleResource is a helper function to simplify the provider implementation.
+func NewRoleResource() resource.Resource {
+	return &roleResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *roleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB role resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	tflog.Info(ctx, "Configured MongoDB role resource")
+}
+
+// Metadata returns the resource type name.
+func (r *roleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+// Schema defines the schema for the resource.
+func (r *roleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create custom roles in MongoDB. A role which already exists is adopted when it is defined as configured.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Database the role is defined in. Defaults to the provider auth_database, or admin.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				Description: "Name of the role.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"privileges": schema.SetNestedAttribute{
+				Description: "Privileges granted by the role.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource": schema.SingleNestedAttribute{
+							Description: "Resource the actions are granted on, either a namespace or the cluster.",
+							Required:    true,
+							Attributes: map[string]schema.Attribute{
+								"db": schema.StringAttribute{
+									Description: "Database of the namespace, empty for any database.",
+									Optional:    true,
+								},
+								"collection": schema.StringAttribute{
+									Description: "Collection of the namespace, empty for any collection.",
+									Optional:    true,
+								},
+								"cluster": schema.BoolAttribute{
+									Description: "Whether the actions are granted on the cluster rather than a namespace.",
+									Optional:    true,
+								},
+							},
+						},
+						"actions": schema.SetAttribute{
+							Description: "Actions granted on the resource.",
+							Required:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"roles": schema.SetNestedAttribute{
+				Description: "Roles the role inherits privileges from.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Description: "Name of the role.",
+							Required:    true,
+						},
+						"db": schema.StringAttribute{
+							Description: "Database the role is defined in.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"authentication_restrictions": authenticationRestrictionsAttribute("users granted the role"),
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan defaults the database of the role to the provider auth database.
+func (r *roleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planUserDatabase(ctx, r.client, req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	if plan.Database.IsUnknown() {
+		plan.Database = types.StringValue(r.client.userDatabase())
+	}
+	databaseName := plan.Database.ValueString()
+	roleName := plan.RoleName
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating role %s.%s", databaseName, roleName))
+
+	command := bson.D{
+		{Key: "createRole", Value: roleName},
+		{Key: "privileges", Value: toMongoPrivileges(plan.Privileges)},
+		{Key: "roles", Value: toMongoRoles(plan.Roles)},
+	}
+	if plan.AuthenticationRestrictions != nil {
+		command = append(command, bson.E{Key: "authenticationRestrictions", Value: toMongoRestrictions(plan.AuthenticationRestrictions)})
+	}
+	err = r.client.Database(databaseName).RunCommand(ctx, command).Err()
+	if isRoleExists(err) {
+		// A role which already exists, e.g. created before the deployment was managed by Terraform, is adopted
+		// when it is defined as planned.
+		tflog.Debug(ctx, fmt.Sprintf("Role %s.%s already exists, checking its definition", databaseName, roleName))
+		var found *roleInfo
+		found, err = readRole(ctx, r.client, databaseName, roleName)
+		if err == nil && found == nil {
+			err = fmt.Errorf("role %s.%s was dropped while being adopted", databaseName, roleName)
+		}
+		var differences []string
+		if err == nil {
+			differences, err = roleDifferences(plan, found)
+		}
+		if err == nil && len(differences) > 0 {
+			resp.Diagnostics.AddError(
+				"Role already exists",
+				fmt.Sprintf("Role %s.%s already exists with a different definition: %s. Please align the configuration "+
+					"with the existing role, or drop it.", databaseName, roleName, strings.Join(differences, "; ")),
+			)
+			return
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create role",
+			"An unexpected error occurred when creating role. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, roleName))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Role %s.%s created", databaseName, roleName))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := r.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database.ValueString()
+	roleName := state.RoleName
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading role %s.%s", databaseName, roleName))
+
+	found, err := readRole(ctx, r.client, databaseName, roleName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read role",
+			"An unexpected error occurred when reading role. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	if found == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Role %s.%s not found, removing it from the state", databaseName, roleName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Privileges, roles and restrictions left unset are read back as null while the role has none.
+	if state.Privileges != nil || len(found.Privileges) > 0 {
+		state.Privileges = normalizePrivileges(state.Privileges, fromMongoPrivileges(found.Privileges))
+	}
+	if state.Roles != nil || len(found.Roles) > 0 {
+		state.Roles = found.Roles
+	}
+	restrictions, err := fromMongoRestrictions(found.AuthenticationRestrictions)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse authentication restrictions",
+			"An unexpected error occurred when parsing the authentication restrictions of role. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	if state.AuthenticationRestrictions != nil || len(restrictions) > 0 {
+		state.AuthenticationRestrictions = restrictions
+	}
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, roleName))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read role %s.%s", databaseName, roleName))
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Changes to database and role name result in resource recreation, the other attributes are replaced in place.
+	var plan roleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := plan.Database.ValueString()
+	roleName := plan.RoleName
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating role %s.%s", databaseName, roleName))
+
+	err = r.client.Database(databaseName).RunCommand(ctx, bson.D{
+		{Key: "updateRole", Value: roleName},
+		{Key: "privileges", Value: toMongoPrivileges(plan.Privileges)},
+		{Key: "roles", Value: toMongoRoles(plan.Roles)},
+		{Key: "authenticationRestrictions", Value: toMongoRestrictions(plan.AuthenticationRestrictions)},
+	}).Err()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update role",
+			"An unexpected error occurred when updating role. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, roleName))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Role %s.%s updated", databaseName, roleName))
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := state.Database.ValueString()
+	roleName := state.RoleName
+
+	tflog.Debug(ctx, fmt.Sprintf("Dropping role %s.%s", databaseName, roleName))
+
+	err = r.client.Database(databaseName).RunCommand(ctx, bson.D{{Key: "dropRole", Value: roleName}}).Err()
+	if err != nil && !isRoleNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to drop role",
+			"An unexpected error occurred when dropping role. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Dropped role %s.%s", databaseName, roleName))
+}
+
+// ImportState imports an existing resource into Terraform state.
+func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Database names cannot contain dots, role names can.
+	database, roleName, found := strings.Cut(req.ID, ".")
+	if !found || database == "" || roleName == "" {
+		resp.Diagnostics.AddError(
+			"Invalid id format. Should be <database>.<role_name>.",
+			fmt.Sprintf("Unable to import role %q, the id must be formatted as <database>.<role_name>.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), database)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), roleName)...)
+}
+
+// readRole reads the role defined in the database with its own privileges, nil if it does not exist.
+func readRole(ctx context.Context, client *mongodbClient, databaseName string, roleName string) (*roleInfo, error) {
+	var result struct {
+		Roles []roleInfo `bson:"roles"`
+	}
+	err := client.readDatabase(databaseName).RunCommand(ctx, bson.D{
+		{Key: "rolesInfo", Value: bson.D{{Key: "role", Value: roleName}, {Key: "db", Value: databaseName}}},
+		{Key: "showPrivileges", Value: true},
+		{Key: "showAuthenticationRestrictions", Value: true},
+	}).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Roles) == 0 {
+		return nil, nil
+	}
+	return &result.Roles[0], nil
+}
+
+// roleDifferences describes how the role found in the database differs from the planned one, nil when it is
+// defined as planned.
+func roleDifferences(plan roleResourceModel, found *roleInfo) ([]string, error) {
+	var differences []string
+	privileges := fromMongoPrivileges(found.Privileges)
+	if !privilegesEqual(privileges, plan.Privileges) {
+		differences = append(differences, fmt.Sprintf("it has privileges %s instead of %s",
+			formatPrivileges(privileges), formatPrivileges(plan.Privileges)))
+	}
+	missing, unexpected := diffRoles(found.Roles, plan.Roles)
+	if len(unexpected) > 0 {
+		differences = append(differences, fmt.Sprintf("it inherits roles %s which are not configured", formatRoles(unexpected)))
+	}
+	if len(missing) > 0 {
+		differences = append(differences, fmt.Sprintf("it does not inherit the configured roles %s", formatRoles(missing)))
+	}
+	restrictions, err := fromMongoRestrictions(found.AuthenticationRestrictions)
+	if err != nil {
+		return nil, err
+	}
+	if !restrictionsEqual(restrictions, plan.AuthenticationRestrictions) {
+		differences = append(differences, fmt.Sprintf("it has authentication restrictions %s instead of %s",
+			formatRestrictions(restrictions), formatRestrictions(plan.AuthenticationRestrictions)))
+	}
+	return differences, nil
+}
+
+// formatPrivileges formats privileges for diagnostics, as the actions granted on cluster or database.collection.
+func formatPrivileges(privileges []rolePrivilege) string {
+	if len(privileges) == 0 {
+		return "none"
+	}
+	formatted := make([]string, 0, len(privileges))
+	for _, p := range privileges {
+		target := "cluster"
+		if !p.Resource.Cluster.ValueBool() {
+			target = p.Resource.Db.ValueString() + "." + p.Resource.Collection.ValueString()
+		}
+		actions := slices.Clone(p.Actions)
+		slices.Sort(actions)
+		formatted = append(formatted, fmt.Sprintf("[%s] on %s", strings.Join(actions, ", "), target))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// toMongoPrivileges converts privileges into the documents expected by the role commands, an empty array for none.
+func toMongoPrivileges(privileges []rolePrivilege) bson.A {
+	res := bson.A{}
+	for _, p := range privileges {
+		var target bson.D
+		if p.Resource.Cluster.ValueBool() {
+			target = bson.D{{Key: "cluster", Value: true}}
+		} else {
+			target = bson.D{
+				{Key: "db", Value: p.Resource.Db.ValueString()},
+				{Key: "collection", Value: p.Resource.Collection.ValueString()},
+			}
+		}
+		res = append(res, bson.D{{Key: "resource", Value: target}, {Key: "actions", Value: p.Actions}})
+	}
+	return res
+}
+
+// fromMongoPrivileges converts the privileges returned by rolesInfo, with their actions sorted.
+func fromMongoPrivileges(privileges []privilege) []rolePrivilege {
+	res := make([]rolePrivilege, 0, len(privileges))
+	for _, p := range privileges {
+		var target rolePrivilegeResource
+		if p.Resource.Cluster {
+			target = rolePrivilegeResource{Db: types.StringNull(), Collection: types.StringNull(), Cluster: types.BoolValue(true)}
+		} else {
+			target = rolePrivilegeResource{
+				Db:         types.StringValue(p.Resource.Db),
+				Collection: types.StringValue(p.Resource.Collection),
+				Cluster:    types.BoolNull(),
+			}
+		}
+		actions := slices.Clone(p.Actions)
+		slices.Sort(actions)
+		res = append(res, rolePrivilege{Resource: target, Actions: actions})
+	}
+	return res
+}
+
+// normalizePrivileges keeps the privileges of the state when they grant the same actions as the ones read, as
+// the server may reorder actions, merge privileges on the same resource, and reports unset namespace fields as
+// empty.
+func normalizePrivileges(current []rolePrivilege, found []rolePrivilege) []rolePrivilege {
+	if privilegesEqual(current, found) {
+		return current
+	}
+	return found
+}
+
+// privilegesEqual checks whether both privileges grant the same actions on the same resources.
+func privilegesEqual(a []rolePrivilege, b []rolePrivilege) bool {
+	grantsA, grantsB := privilegeGrants(a), privilegeGrants(b)
+	if len(grantsA) != len(grantsB) {
+		return false
+	}
+	for target, actions := range grantsA {
+		other, ok := grantsB[target]
+		if !ok || len(actions) != len(other) {
+			return false
+		}
+		for action := range actions {
+			if !other[action] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// privilegeGrants indexes the actions granted by the privileges per resource.
+func privilegeGrants(privileges []rolePrivilege) map[privilegeResource]map[string]bool {
+	res := map[privilegeResource]map[string]bool{}
+	for _, p := range privileges {
+		var target privilegeResource
+		if p.Resource.Cluster.ValueBool() {
+			target = privilegeResource{Cluster: true}
+		} else {
+			target = privilegeResource{Db: p.Resource.Db.ValueString(), Collection: p.Resource.Collection.ValueString()}
+		}
+		if res[target] == nil {
+			res[target] = map[string]bool{}
+		}
+		for _, action := range p.Actions {
+			res[target][action] = true
+		}
+	}
+	return res
+}
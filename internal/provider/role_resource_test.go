@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNormalizePrivileges(t *testing.T) {
+	orders := rolePrivilege{
+		Resource: rolePrivilegeResource{Db: types.StringValue("shop"), Collection: types.StringValue("orders"), Cluster: types.BoolNull()},
+		Actions:  []string{"insert", "find"},
+	}
+	cluster := rolePrivilege{
+		Resource: rolePrivilegeResource{Db: types.StringNull(), Collection: types.StringNull(), Cluster: types.BoolValue(true)},
+		Actions:  []string{"serverStatus"},
+	}
+
+	tests := map[string]struct {
+		found   []privilege
+		changed bool
+	}{
+		"reordered actions": {
+			found: []privilege{
+				{Resource: privilegeResource{Db: "shop", Collection: "orders"}, Actions: []string{"find", "insert"}},
+				{Resource: privilegeResource{Cluster: true}, Actions: []string{"serverStatus"}},
+			},
+		},
+		"reordered privileges": {
+			found: []privilege{
+				{Resource: privilegeResource{Cluster: true}, Actions: []string{"serverStatus"}},
+				{Resource: privilegeResource{Db: "shop", Collection: "orders"}, Actions: []string{"insert", "find"}},
+			},
+		},
+		"missing action": {
+			found: []privilege{
+				{Resource: privilegeResource{Db: "shop", Collection: "orders"}, Actions: []string{"find"}},
+				{Resource: privilegeResource{Cluster: true}, Actions: []string{"serverStatus"}},
+			},
+			changed: true,
+		},
+		"other collection": {
+			found: []privilege{
+				{Resource: privilegeResource{Db: "shop", Collection: "invoices"}, Actions: []string{"find", "insert"}},
+				{Resource: privilegeResource{Cluster: true}, Actions: []string{"serverStatus"}},
+			},
+			changed: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			current := []rolePrivilege{orders, cluster}
+			found := fromMongoPrivileges(test.found)
+			normalized := normalizePrivileges(current, found)
+			if test.changed {
+				if !privilegesEqual(normalized, found) || privilegesEqual(normalized, current) {
+					t.Errorf("Expected the privileges read, got %v", normalized)
+				}
+			} else if &normalized[0] != &current[0] {
+				t.Errorf("Expected the privileges of the state to be kept, got %v", normalized)
+			}
+		})
+	}
+}
+
+func TestFromMongoPrivilegesSortsActions(t *testing.T) {
+	privileges := fromMongoPrivileges([]privilege{
+		{Resource: privilegeResource{Db: "shop"}, Actions: []string{"update", "find", "insert"}},
+	})
+	if fmt.Sprint(privileges[0].Actions) != "[find insert update]" {
+		t.Errorf("Expected sorted actions, got %v", privileges[0].Actions)
+	}
+	if privileges[0].Resource.Collection.ValueString() != "" || !privileges[0].Resource.Cluster.IsNull() {
+		t.Errorf("Expected a database resource, got %v", privileges[0].Resource)
+	}
+}
+
+func TestRoleDifferences(t *testing.T) {
+	found := &roleInfo{
+		Privileges: []privilege{{Resource: privilegeResource{Db: "shop", Collection: "orders"}, Actions: []string{"insert", "find"}}},
+	}
+
+	plan := roleResourceModel{Privileges: []rolePrivilege{{
+		Resource: rolePrivilegeResource{Db: types.StringValue("shop"), Collection: types.StringValue("orders")},
+		Actions:  []string{"find", "insert"},
+	}}}
+	if differences, err := roleDifferences(plan, found); differences != nil || err != nil {
+		t.Errorf("Expected no difference, got %v and %v", differences, err)
+	}
+
+	plan = roleResourceModel{
+		Privileges: []rolePrivilege{{Resource: rolePrivilegeResource{Cluster: types.BoolValue(true)}, Actions: []string{"serverStatus"}}},
+		Roles:      []userRole{{Role: "read", Db: "shop"}},
+	}
+	want := []string{
+		"it has privileges [find, insert] on shop.orders instead of [serverStatus] on cluster",
+		"it does not inherit the configured roles shop.read",
+	}
+	if differences, err := roleDifferences(plan, found); !reflect.DeepEqual(differences, want) || err != nil {
+		t.Errorf("Expected %v, got %v and %v", want, differences, err)
+	}
+}
+
+func TestAccRoleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_role" "orders" {
+	database  = "test_roles"
+	role_name = "orders_writer"
+	privileges = [
+		{
+			resource = { db = "test_roles", collection = "orders" }
+			actions  = ["update", "insert", "find"]
+		},
+		{
+			resource = { cluster = true }
+			actions  = ["serverStatus"]
+		},
+	]
+	roles = [
+		{ role = "read", db = "test_reporting" },
+	]
+}
+`,
+				// The server returns the actions in its own order, which must not show as a diff.
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_role.orders", "privileges.#", "2"),
+					resource.TestCheckResourceAttr("mongodb_role.orders", "roles.#", "1"),
+					testAccCheckRolePrivileges(t, "test_roles", "orders_writer", 2),
+				),
+			},
+			{
+				ResourceName:      "mongodb_role.orders",
+				ImportStateId:     "test_roles.orders_writer",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Privileges are read back in the server order, which the flattened state comparison does not ignore.
+				ImportStateVerifyIgnore: []string{"privileges"},
+			},
+			// Privileges and roles are replaced in place.
+			{
+				Config: providerConfig + `
+resource "mongodb_role" "orders" {
+	database  = "test_roles"
+	role_name = "orders_writer"
+	privileges = [
+		{
+			resource = { db = "test_roles", collection = "orders" }
+			actions  = ["find"]
+		},
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_role.orders", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_role.orders", "privileges.#", "1"),
+					resource.TestCheckNoResourceAttr("mongodb_role.orders", "roles"),
+					testAccCheckRolePrivileges(t, "test_roles", "orders_writer", 1),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckRolePrivileges checks the role exists in the database with the number of privileges.
+func testAccCheckRolePrivileges(t *testing.T, databaseName string, roleName string, count int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		found, err := readRole(context.Background(), &mongodbClient{Client: testAccClient(t)}, databaseName, roleName)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("role %s not found in database %s", roleName, databaseName)
+		}
+		if len(found.Privileges) != count {
+			return fmt.Errorf("expected %d privileges, got %v", count, found.Privileges)
+		}
+		return nil
+	}
+}
+
+func TestAccRoleResourceImport(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_role" "imported" {
+	database = "test_roles"
+	role_name = "imported"
+	privileges = [
+		{
+			resource = { db = "test_roles", collection = "orders" }
+			actions = ["find", "insert"]
+		},
+	]
+	roles = [
+		{ role = "read", db = "test_roles" },
+	]
+	authentication_restrictions = [
+		{ client_source = ["127.0.0.1"], server_address = ["127.0.0.1"] },
+	]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			err := testAccClient(t).Database("test_roles").RunCommand(context.Background(), bson.D{
+				{Key: "createRole", Value: "imported"},
+				{Key: "privileges", Value: bson.A{bson.D{
+					{Key: "resource", Value: bson.D{{Key: "db", Value: "test_roles"}, {Key: "collection", Value: "orders"}}},
+					{Key: "actions", Value: bson.A{"insert", "find"}},
+				}}},
+				{Key: "roles", Value: bson.A{bson.D{{Key: "role", Value: "read"}, {Key: "db", Value: "test_roles"}}}},
+				{Key: "authenticationRestrictions", Value: bson.A{bson.D{
+					{Key: "clientSource", Value: bson.A{"127.0.0.1"}},
+					{Key: "serverAddress", Value: bson.A{"127.0.0.1"}},
+				}}},
+			}).Err()
+			if err != nil {
+				t.Fatalf("Unable to create role: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ResourceName:       "mongodb_role.imported",
+				ImportStateId:      "test_roles.imported",
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+// A role which already exists is adopted when it is defined as configured, and is an error otherwise.
+func TestAccRoleResourceAdopt(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			db := testAccClient(t).Database("test_roles")
+			for _, roleName := range []string{"adopted", "mismatched"} {
+				err := db.RunCommand(context.Background(), bson.D{
+					{Key: "createRole", Value: roleName},
+					{Key: "privileges", Value: bson.A{bson.D{
+						{Key: "resource", Value: bson.D{{Key: "db", Value: "test_roles"}, {Key: "collection", Value: "orders"}}},
+						{Key: "actions", Value: bson.A{"insert", "find"}},
+					}}},
+					{Key: "roles", Value: bson.A{}},
+				}).Err()
+				if err != nil {
+					t.Fatalf("Unable to create role: %v", err)
+				}
+			}
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			return testAccClient(t).Database("test_roles").RunCommand(context.Background(), bson.D{{Key: "dropRole", Value: "mismatched"}}).Err()
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_role" "mismatched" {
+	database = "test_roles"
+	role_name = "mismatched"
+	privileges = [
+		{
+			resource = { db = "test_roles", collection = "orders" }
+			actions = ["find"]
+		},
+	]
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)already exists with a different definition:.*it has privileges\s+\[find,\s+insert\]\s+on\s+test_roles.orders\s+instead\s+of\s+\[find\]`),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_role" "adopted" {
+	database = "test_roles"
+	role_name = "adopted"
+	privileges = [
+		{
+			resource = { db = "test_roles", collection = "orders" }
+			actions = ["find", "insert"]
+		},
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("mongodb_role.adopted", "id", "test_roles.adopted"),
+			},
+		},
+	})
+}
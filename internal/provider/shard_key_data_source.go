@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &shardKeyDataSource{}
+	_ datasource.DataSourceWithConfigure = &shardKeyDataSource{}
+)
+
+// shardKeyDataSource is the data source implementation.
+type shardKeyDataSource struct {
+	client *mongodbClient
+}
+
+// shardKeyDataSourceModel maps the data source schema data.
+type shardKeyDataSourceModel struct {
+	Database   string       `tfsdk:"database"`
+	Collection string       `tfsdk:"collection"`
+	Sharded    bool         `tfsdk:"sharded"`
+	Keys       []indexKey   `tfsdk:"keys"`
+	Hashed     types.Bool   `tfsdk:"hashed"`
+	Unique     types.Bool   `tfsdk:"unique"`
+	Id         types.String `tfsdk:"id"`
+}
+
+// shardedCollection maps the documents of config.collections describing a sharded collection.
+type shardedCollection struct {
+	Key    bson.Raw `bson:"key"`
+	Unique bool     `bson:"unique"`
+}
+
+// NewShardKeyDataSource is a helper function to simplify the provider implementation.
+func NewShardKeyDataSource() datasource.DataSource {
+	return &shardKeyDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *shardKeyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB shard key data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB shard key data source")
+}
+
+// Metadata returns the data source type name.
+func (d *shardKeyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shard_key"
+}
+
+// Schema defines the schema for the data source.
+func (d *shardKeyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read the shard key of a collection, e.g. to verify how it is sharded. The collection is reported as not " +
+			"sharded when it is not, or when the server is not a mongos of a sharded cluster.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database of the collection.",
+				Required:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "Name of the collection.",
+				Required:    true,
+			},
+			"sharded": schema.BoolAttribute{
+				Description: "Whether the collection is sharded.",
+				Computed:    true,
+			},
+			"keys": schema.ListNestedAttribute{
+				Description: "Fields of the shard key, in order, empty when the collection is not sharded.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Description: "Name of the field.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the field, asc for ranged sharding or hashed.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"hashed": schema.BoolAttribute{
+				Description: "Whether the shard key has a hashed field, null when the collection is not sharded.",
+				Computed:    true,
+			},
+			"unique": schema.BoolAttribute{
+				Description: "Whether the shard key is unique, null when the collection is not sharded.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *shardKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state shardKeyDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	namespace := fmt.Sprintf("%s.%s", state.Database, state.Collection)
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading shard key of %s", namespace))
+
+	sharded, err := isShardedCluster(ctx, d.client.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to get server topology",
+			"An unexpected error occurred when getting server topology. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	var found *shardedCollection
+	if !sharded {
+		tflog.Info(ctx, fmt.Sprintf("Server is not a mongos, %s is not sharded", namespace))
+	} else {
+		var collection shardedCollection
+		err = d.client.Database("config").Collection("collections").FindOne(ctx, bson.D{
+			{Key: "_id", Value: namespace},
+			{Key: "dropped", Value: bson.D{{Key: "$ne", Value: true}}},
+		}).Decode(&collection)
+		switch {
+		case errors.Is(err, mongo.ErrNoDocuments):
+			tflog.Info(ctx, fmt.Sprintf("Collection %s is not sharded", namespace))
+		case err != nil:
+			resp.Diagnostics.AddError(
+				"Unable to read sharded collection",
+				"An unexpected error occurred when reading sharded collection. "+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		default:
+			found = &collection
+		}
+	}
+
+	err = state.setShardKey(found)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse shard key",
+			"An unexpected error occurred when parsing the shard key. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.Id = types.StringValue(namespace)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read shard key of %s, sharded: %t", namespace, state.Sharded))
+}
+
+// setShardKey sets the attributes from the sharded collection, nil when the collection is not sharded.
+func (m *shardKeyDataSourceModel) setShardKey(collection *shardedCollection) error {
+	m.Sharded = collection != nil
+	m.Keys = []indexKey{}
+	m.Hashed = types.BoolNull()
+	m.Unique = types.BoolNull()
+	if collection == nil {
+		return nil
+	}
+
+	elements, err := collection.Key.Elements()
+	if err != nil {
+		return err
+	}
+	hashed := false
+	for _, element := range elements {
+		// The ranged fields are stored as numbers of any type, depending on the client sharding the collection.
+		keyType, isString := element.Value().StringValueOK()
+		if !isString {
+			number, isNumber := element.Value().AsInt64OK()
+			if !isNumber || number != 1 {
+				return fmt.Errorf("unexpected type %s of shard key field %s", element.Value(), element.Key())
+			}
+			keyType = "asc"
+		}
+		hashed = hashed || keyType == "hashed"
+		m.Keys = append(m.Keys, indexKey{Field: element.Key(), Type: keyType})
+	}
+	m.Hashed = types.BoolValue(hashed)
+	m.Unique = types.BoolValue(collection.Unique)
+	return nil
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestShardKeyDataSourceModelSetShardKey(t *testing.T) {
+	key, _ := bson.Marshal(bson.D{{Key: "customer", Value: float64(1)}, {Key: "_id", Value: "hashed"}})
+
+	var model shardKeyDataSourceModel
+	if err := model.setShardKey(&shardedCollection{Key: key, Unique: false}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []indexKey{{Field: "customer", Type: "asc"}, {Field: "_id", Type: "hashed"}}
+	if !model.Sharded || !reflect.DeepEqual(model.Keys, want) || !model.Hashed.ValueBool() || model.Unique.ValueBool() {
+		t.Errorf("Unexpected shard key %+v", model)
+	}
+
+	key, _ = bson.Marshal(bson.D{{Key: "email", Value: int32(1)}})
+	if err := model.setShardKey(&shardedCollection{Key: key, Unique: true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if model.Hashed.ValueBool() || !model.Unique.ValueBool() || len(model.Keys) != 1 {
+		t.Errorf("Unexpected shard key %+v", model)
+	}
+
+	if err := model.setShardKey(nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if model.Sharded || len(model.Keys) != 0 || !model.Hashed.IsNull() || !model.Unique.IsNull() {
+		t.Errorf("Expected no shard key, got %+v", model)
+	}
+}
+
+func TestAccShardKeyDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckSharded(t)
+
+			admin := testAccClient(t).Database("admin")
+			_ = admin.RunCommand(context.Background(), bson.D{{Key: "enableSharding", Value: "test_sharded"}}).Err()
+			err := admin.RunCommand(context.Background(), bson.D{
+				{Key: "shardCollection", Value: "test_sharded.shard_key"},
+				{Key: "key", Value: bson.D{{Key: "customer", Value: 1}, {Key: "order", Value: 1}}},
+				{Key: "unique", Value: true},
+			}).Err()
+			if err != nil {
+				t.Fatalf("Unable to shard collection: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_shard_key" "sharded" {
+	database = "test_sharded"
+	collection = "shard_key"
+}
+
+data "mongodb_shard_key" "unsharded" {
+	database = "test_sharded"
+	collection = "unsharded"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.sharded", "sharded", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.sharded", "keys.#", "2"),
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.sharded", "keys.0.field", "customer"),
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.sharded", "keys.0.type", "asc"),
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.sharded", "keys.1.field", "order"),
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.sharded", "hashed", "false"),
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.sharded", "unique", "true"),
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.unsharded", "sharded", "false"),
+					resource.TestCheckResourceAttr("data.mongodb_shard_key.unsharded", "keys.#", "0"),
+					resource.TestCheckNoResourceAttr("data.mongodb_shard_key.unsharded", "unique"),
+				),
+			},
+		},
+	})
+}
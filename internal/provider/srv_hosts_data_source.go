@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &srvHostsDataSource{}
+)
+
+// srvResolver looks up the DNS records of SRV connection strings, implemented by net.Resolver.
+type srvResolver interface {
+	LookupSRV(ctx context.Context, service string, proto string, name string) (string, []*net.SRV, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// srvHostsDataSource is the data source implementation.
+type srvHostsDataSource struct {
+	resolver srvResolver
+}
+
+// srvHostsDataSourceModel maps the data source schema data.
+type srvHostsDataSourceModel struct {
+	SrvHost string       `tfsdk:"srv_host"`
+	Hosts   []string     `tfsdk:"hosts"`
+	Options types.String `tfsdk:"options"`
+	Id      types.String `tfsdk:"id"`
+}
+
+// NewSrvHostsDataSource is a helper function to simplify the provider implementation.
+func NewSrvHostsDataSource() datasource.DataSource {
+	return &srvHostsDataSource{resolver: net.DefaultResolver}
+}
+
+// Metadata returns the data source type name.
+func (d *srvHostsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_srv_hosts"
+}
+
+// Schema defines the schema for the data source.
+func (d *srvHostsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resolve the DNS records of a mongodb+srv connection string into the seed hosts and default options.",
+		Attributes: map[string]schema.Attribute{
+			"srv_host": schema.StringAttribute{
+				Description: "Host of the mongodb+srv connection string, e.g. cluster.example.net.",
+				Required:    true,
+			},
+			"hosts": schema.ListAttribute{
+				Description: "Seed hosts of the cluster as host:port, from the _mongodb._tcp SRV record.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"options": schema.StringAttribute{
+				Description: "Default connection options from the TXT record, e.g. replicaSet=rs0&authSource=admin, empty without TXT record.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *srvHostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state srvHostsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Resolving SRV records of %s", state.SrvHost))
+
+	hosts, options, err := resolveSRVHosts(ctx, d.resolver, state.SrvHost)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to resolve SRV records",
+			"An unexpected error occurred when resolving SRV records. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Hosts = hosts
+	state.Options = types.StringValue(options)
+	state.Id = types.StringValue(state.SrvHost)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Resolved SRV records of %s", state.SrvHost))
+}
+
+// Resolve the seed hosts and default options of a mongodb+srv connection string. As done by the driver, hosts
+// must be in the parent domain of srvHost, and there must be at most one TXT record.
+func resolveSRVHosts(ctx context.Context, resolver srvResolver, srvHost string) ([]string, string, error) {
+	srvHost = strings.TrimSuffix(srvHost, ".")
+	labels := strings.Split(srvHost, ".")
+	if len(labels) < 3 {
+		return nil, "", fmt.Errorf("srv host %s must have at least three labels, e.g. cluster.example.net", srvHost)
+	}
+	parentDomain := "." + strings.Join(labels[1:], ".")
+
+	_, records, err := resolver.LookupSRV(ctx, "mongodb", "tcp", srvHost)
+	if err != nil {
+		return nil, "", err
+	}
+
+	hosts := make([]string, 0, len(records))
+	for _, record := range records {
+		target := strings.TrimSuffix(record.Target, ".")
+		if !strings.HasSuffix(target, parentDomain) {
+			return nil, "", fmt.Errorf("host %s is not in the domain %s", target, parentDomain[1:])
+		}
+		hosts = append(hosts, net.JoinHostPort(target, strconv.Itoa(int(record.Port))))
+	}
+
+	txtRecords, err := resolver.LookupTXT(ctx, srvHost)
+	if err != nil {
+		// A missing TXT record is not an error, there are just no default options.
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return hosts, "", nil
+		}
+		return nil, "", err
+	}
+	if len(txtRecords) > 1 {
+		return nil, "", fmt.Errorf("found %d TXT records for %s, expected at most one", len(txtRecords), srvHost)
+	}
+
+	return hosts, strings.Join(txtRecords, ""), nil
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// stubResolver returns fixed DNS records.
+type stubResolver struct {
+	srv []*net.SRV
+	txt []string
+}
+
+func (r *stubResolver) LookupSRV(_ context.Context, _ string, _ string, _ string) (string, []*net.SRV, error) {
+	return "", r.srv, nil
+}
+
+func (r *stubResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if r.txt == nil {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return r.txt, nil
+}
+
+func TestResolveSRVHosts(t *testing.T) {
+	resolver := &stubResolver{
+		srv: []*net.SRV{
+			{Target: "node1.example.net.", Port: 27017},
+			{Target: "node2.example.net.", Port: 27018},
+		},
+		txt: []string{"replicaSet=rs0&authSource=admin"},
+	}
+
+	hosts, options, err := resolveSRVHosts(context.Background(), resolver, "cluster.example.net")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"node1.example.net:27017", "node2.example.net:27018"}; !reflect.DeepEqual(hosts, want) {
+		t.Errorf("Expected hosts %v, got %v", want, hosts)
+	}
+	if options != "replicaSet=rs0&authSource=admin" {
+		t.Errorf("Expected TXT options, got %q", options)
+	}
+}
+
+func TestResolveSRVHostsWithoutTXT(t *testing.T) {
+	resolver := &stubResolver{srv: []*net.SRV{{Target: "node1.example.net.", Port: 27017}}}
+
+	_, options, err := resolveSRVHosts(context.Background(), resolver, "cluster.example.net")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options != "" {
+		t.Errorf("Expected no options, got %q", options)
+	}
+}
+
+func TestResolveSRVHostsInvalid(t *testing.T) {
+	cases := map[string]*stubResolver{
+		"example.net":         {srv: []*net.SRV{{Target: "node1.example.net.", Port: 27017}}},
+		"cluster.example.net": {srv: []*net.SRV{{Target: "node1.attacker.com.", Port: 27017}}},
+	}
+	for srvHost, resolver := range cases {
+		if _, _, err := resolveSRVHosts(context.Background(), resolver, srvHost); err == nil {
+			t.Errorf("Expected an error for %s resolving to %s", srvHost, resolver.srv[0].Target)
+		}
+	}
+
+	resolver := &stubResolver{
+		srv: []*net.SRV{{Target: "node1.example.net.", Port: 27017}},
+		txt: []string{"replicaSet=rs0", "authSource=admin"},
+	}
+	if _, _, err := resolveSRVHosts(context.Background(), resolver, "cluster.example.net"); err == nil {
+		t.Errorf("Expected an error for multiple TXT records")
+	}
+}
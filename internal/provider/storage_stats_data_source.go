@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &storageStatsDataSource{}
+	_ datasource.DataSourceWithConfigure = &storageStatsDataSource{}
+)
+
+// storageStatsDataSource is the data source implementation.
+type storageStatsDataSource struct {
+	client *mongodbClient
+}
+
+// storageStatsDataSourceModel maps the data source schema data.
+type storageStatsDataSourceModel struct {
+	StorageEngine          types.String `tfsdk:"storage_engine"`
+	CacheMaxBytes          types.Int64  `tfsdk:"cache_max_bytes"`
+	CacheBytes             types.Int64  `tfsdk:"cache_bytes"`
+	CacheDirtyBytes        types.Int64  `tfsdk:"cache_dirty_bytes"`
+	UnmodifiedPagesEvicted types.Int64  `tfsdk:"unmodified_pages_evicted"`
+	ModifiedPagesEvicted   types.Int64  `tfsdk:"modified_pages_evicted"`
+	Id                     types.String `tfsdk:"id"`
+}
+
+// wiredTigerCacheStats maps the attributes of the data source to the WiredTiger cache statistics of serverStatus.
+var wiredTigerCacheStats = map[string]string{
+	"cache_max_bytes":          "maximum bytes configured",
+	"cache_bytes":              "bytes currently in the cache",
+	"cache_dirty_bytes":        "tracked dirty bytes in the cache",
+	"unmodified_pages_evicted": "unmodified pages evicted",
+	"modified_pages_evicted":   "modified pages evicted",
+}
+
+// NewStorageStatsDataSource is a helper function to simplify the provider implementation.
+func NewStorageStatsDataSource() datasource.DataSource {
+	return &storageStatsDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *storageStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB storage stats data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB storage stats data source")
+}
+
+// Metadata returns the data source type name.
+func (d *storageStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_stats"
+}
+
+// Schema defines the schema for the data source.
+func (d *storageStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read the storage engine statistics of the server. The cache statistics are those of the WiredTiger cache, " +
+			"and are null with other storage engines.",
+		Attributes: map[string]schema.Attribute{
+			"storage_engine": schema.StringAttribute{
+				Description: "Name of the storage engine, e.g. wiredTiger.",
+				Computed:    true,
+			},
+			"cache_max_bytes": schema.Int64Attribute{
+				Description: "Configured size of the cache.",
+				Computed:    true,
+			},
+			"cache_bytes": schema.Int64Attribute{
+				Description: "Size of the data currently in the cache.",
+				Computed:    true,
+			},
+			"cache_dirty_bytes": schema.Int64Attribute{
+				Description: "Size of the modified data in the cache, not written to disk yet.",
+				Computed:    true,
+			},
+			"unmodified_pages_evicted": schema.Int64Attribute{
+				Description: "Number of unmodified pages evicted from the cache since the server started.",
+				Computed:    true,
+			},
+			"modified_pages_evicted": schema.Int64Attribute{
+				Description: "Number of modified pages evicted from the cache since the server started, which had to be written first.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *storageStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	tflog.Debug(ctx, "Reading storage stats")
+
+	status, err := d.client.Database("admin").RunCommand(ctx, bson.D{{Key: "serverStatus", Value: 1}}).Raw()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read server status",
+			"An unexpected error occurred when reading server status. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	state := toStorageStats(status)
+	if state.CacheMaxBytes.IsNull() {
+		tflog.Info(ctx, fmt.Sprintf("Storage engine %s has no WiredTiger cache statistics", state.StorageEngine.ValueString()))
+	}
+	state.Id = types.StringValue("to_be_ignored")
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Read storage stats")
+}
+
+// toStorageStats converts the serverStatus reply, the cache statistics being null when missing.
+func toStorageStats(status bson.Raw) storageStatsDataSourceModel {
+	cacheStat := func(attribute string) types.Int64 {
+		value, err := status.LookupErr("wiredTiger", "cache", wiredTigerCacheStats[attribute])
+		if err != nil {
+			return types.Int64Null()
+		}
+		if number, ok := value.AsInt64OK(); ok {
+			return types.Int64Value(number)
+		}
+		return types.Int64Null()
+	}
+
+	storageEngine := types.StringNull()
+	if name, ok := status.Lookup("storageEngine", "name").StringValueOK(); ok {
+		storageEngine = types.StringValue(name)
+	}
+
+	return storageStatsDataSourceModel{
+		StorageEngine:          storageEngine,
+		CacheMaxBytes:          cacheStat("cache_max_bytes"),
+		CacheBytes:             cacheStat("cache_bytes"),
+		CacheDirtyBytes:        cacheStat("cache_dirty_bytes"),
+		UnmodifiedPagesEvicted: cacheStat("unmodified_pages_evicted"),
+		ModifiedPagesEvicted:   cacheStat("modified_pages_evicted"),
+	}
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestToStorageStats(t *testing.T) {
+	status, _ := bson.Marshal(bson.D{
+		{Key: "storageEngine", Value: bson.D{{Key: "name", Value: "wiredTiger"}}},
+		{Key: "wiredTiger", Value: bson.D{{Key: "cache", Value: bson.D{
+			{Key: "maximum bytes configured", Value: float64(1 << 30)},
+			{Key: "bytes currently in the cache", Value: int64(1 << 20)},
+			{Key: "tracked dirty bytes in the cache", Value: int32(1024)},
+			{Key: "unmodified pages evicted", Value: int64(3)},
+			{Key: "modified pages evicted", Value: int64(2)},
+		}}}},
+	})
+
+	stats := toStorageStats(status)
+	if stats.StorageEngine.ValueString() != "wiredTiger" || stats.CacheMaxBytes.ValueInt64() != 1<<30 || stats.CacheBytes.ValueInt64() != 1<<20 ||
+		stats.CacheDirtyBytes.ValueInt64() != 1024 || stats.UnmodifiedPagesEvicted.ValueInt64() != 3 || stats.ModifiedPagesEvicted.ValueInt64() != 2 {
+		t.Errorf("Unexpected WiredTiger stats %+v", stats)
+	}
+
+	status, _ = bson.Marshal(bson.D{
+		{Key: "storageEngine", Value: bson.D{{Key: "name", Value: "inMemory"}}},
+	})
+	stats = toStorageStats(status)
+	if stats.StorageEngine.ValueString() != "inMemory" || !stats.CacheMaxBytes.IsNull() || !stats.CacheBytes.IsNull() ||
+		!stats.CacheDirtyBytes.IsNull() || !stats.UnmodifiedPagesEvicted.IsNull() || !stats.ModifiedPagesEvicted.IsNull() {
+		t.Errorf("Expected null cache stats for another storage engine, got %+v", stats)
+	}
+}
+
+func TestAccStorageStatsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_storage_stats" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mongodb_storage_stats.test", "storage_engine", "wiredTiger"),
+					resource.TestCheckResourceAttrSet("data.mongodb_storage_stats.test", "cache_max_bytes"),
+					resource.TestCheckResourceAttrSet("data.mongodb_storage_stats.test", "cache_bytes"),
+				),
+			},
+		},
+	})
+}
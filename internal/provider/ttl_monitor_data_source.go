@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ttlMonitorDataSource{}
+	_ datasource.DataSourceWithConfigure = &ttlMonitorDataSource{}
+)
+
+// ttlMonitorDataSource is the data source implementation.
+type ttlMonitorDataSource struct {
+	client *mongodbClient
+}
+
+// ttlMonitorDataSourceModel maps the data source schema data.
+type ttlMonitorDataSourceModel struct {
+	SleepSeconds types.Int64  `tfsdk:"ttl_monitor_sleep_seconds"`
+	Id           types.String `tfsdk:"id"`
+}
+
+// NewTTLMonitorDataSource is a helper function to simplify the provider implementation.
+func NewTTLMonitorDataSource() datasource.DataSource {
+	return &ttlMonitorDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ttlMonitorDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB ttl monitor data source")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+	tflog.Info(ctx, "Configured MongoDB ttl monitor data source")
+}
+
+// Metadata returns the data source type name.
+func (d *ttlMonitorDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ttl_monitor"
+}
+
+// Schema defines the schema for the data source.
+func (d *ttlMonitorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Read the configuration of the ttl monitor, the background task removing the documents expired by ttl indexes.",
+		Attributes: map[string]schema.Attribute{
+			"ttl_monitor_sleep_seconds": schema.Int64Attribute{
+				Description: "Seconds between two runs of the ttl monitor, so documents may be removed up to this long after they expire. " +
+					"Null when the provider user is not granted getParameter.",
+				Computed: true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ttlMonitorDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ttlMonitorDataSourceModel
+
+	ctx, release := d.client.withSession(ctx)
+	defer release()
+
+	tflog.Debug(ctx, "Reading ttl monitor parameters")
+
+	var parameters struct {
+		TTLMonitorSleepSecs int64 `bson:"ttlMonitorSleepSecs"`
+	}
+	err := d.client.Database("admin").RunCommand(ctx, bson.D{
+		{Key: "getParameter", Value: 1},
+		{Key: "ttlMonitorSleepSecs", Value: 1},
+	}).Decode(&parameters)
+	switch {
+	case isUnauthorized(err):
+		resp.Diagnostics.AddWarning(
+			"Unable to read ttl monitor parameters",
+			"The provider user is not granted the getParameter action on the cluster, ttl_monitor_sleep_seconds is unknown. "+
+				"The ttl monitor runs every 60 seconds by default.",
+		)
+		state.SleepSeconds = types.Int64Null()
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Unable to read ttl monitor parameters",
+			"An unexpected error occurred when reading ttl monitor parameters. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	default:
+		state.SleepSeconds = types.Int64Value(parameters.TTLMonitorSleepSecs)
+	}
+	state.Id = types.StringValue("to_be_ignored")
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Read ttl monitor parameters")
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTTLMonitorDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "mongodb_ttl_monitor" "test" {}
+`,
+				Check: resource.TestCheckResourceAttrWith("data.mongodb_ttl_monitor.test", "ttl_monitor_sleep_seconds", func(value string) error {
+					seconds, err := strconv.ParseInt(value, 10, 64)
+					if err != nil {
+						return err
+					}
+					if seconds <= 0 {
+						return fmt.Errorf("expected a positive ttl monitor sleep, got %d", seconds)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
@@ -0,0 +1,694 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &userResource{}
+	_ resource.ResourceWithConfigure   = &userResource{}
+	_ resource.ResourceWithImportState = &userResource{}
+	_ resource.ResourceWithModifyPlan  = &userResource{}
+)
+
+// userResource is the resource implementation.
+type userResource struct {
+	client *mongodbClient
+}
+
+// userResourceModel maps the resource schema data.
+type userResourceModel struct {
+	Database types.String `tfsdk:"database"`
+	Username string       `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Roles    []userRole   `tfsdk:"roles"`
+	// Mechanisms is null when the user has the default mechanisms.
+	Mechanisms                 []string                    `tfsdk:"mechanisms"`
+	AuthenticationRestrictions []authenticationRestriction `tfsdk:"authentication_restrictions"`
+	Id                         types.String                `tfsdk:"id"`
+}
+
+// authenticationRestriction maps a restriction of the addresses users and roles can authenticate from and to.
+type authenticationRestriction struct {
+	ClientSource  []string `tfsdk:"client_source" bson:"clientSource,omitempty"`
+	ServerAddress []string `tfsdk:"server_address" bson:"serverAddress,omitempty"`
+}
+
+// userInfo maps the users returned by usersInfo.
+type userInfo struct {
+	User                       string        `bson:"user"`
+	Db                         string        `bson:"db"`
+	Roles                      []userRole    `bson:"roles"`
+	Mechanisms                 []string      `bson:"mechanisms"`
+	AuthenticationRestrictions bson.RawValue `bson:"authenticationRestrictions"`
+}
+
+// defaultMechanisms are the mechanisms of the users created without mechanisms.
+var defaultMechanisms = []string{"SCRAM-SHA-1", "SCRAM-SHA-256"}
+
+// NewUserResource is a helper function to simplify the provider implementation.
+func NewUserResource() resource.Resource {
+	return &userResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *userResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB user resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	tflog.Info(ctx, "Configured MongoDB user resource")
+}
+
+// Metadata returns the resource type name.
+func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema defines the schema for the resource.
+func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create database users in MongoDB. A user which already exists is adopted when it is defined as configured.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Database the user is defined in, which authenticates it. Defaults to the provider auth_database, or admin.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Name of the user.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the user. Changing it updates the user in place. " +
+					"It cannot be read back, so it is not checked for drift and is empty after import, the first apply after import " +
+					"setting it again.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"mechanisms": schema.SetAttribute{
+				Description: "SCRAM mechanisms the user can authenticate with, a subset of SCRAM-SHA-1 and SCRAM-SHA-256. " +
+					"Defaults to both. Adding a mechanism in place requires the password.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(defaultMechanisms...)),
+				},
+			},
+			"authentication_restrictions": authenticationRestrictionsAttribute("user"),
+			"roles": schema.SetNestedAttribute{
+				Description: "Roles granted to the user.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Description: "Name of the role.",
+							Required:    true,
+						},
+						"db": schema.StringAttribute{
+							Description: "Database the role is defined in.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan defaults the database of the user to the provider auth database.
+func (r *userResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planUserDatabase(ctx, r.client, req, resp)
+}
+
+// planUserDatabase plans the database of users and roles which don't configure it, the provider auth database
+// or admin.
+func planUserDatabase(ctx context.Context, client *mongodbClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy, nor before the provider is configured
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+
+	databasePath := path.Root("database")
+	var database types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, databasePath, &database)...)
+	if resp.Diagnostics.HasError() || !database.IsNull() {
+		return
+	}
+
+	database = types.StringValue(client.userDatabase())
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, databasePath, database)...)
+
+	// The database is set after its own plan modifiers ran, so moving to another database requires the
+	// replacement here.
+	if !req.State.Raw.IsNull() {
+		var current types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, databasePath, &current)...)
+		if !database.Equal(current) {
+			resp.RequiresReplace = append(resp.RequiresReplace, databasePath)
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan userResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	if plan.Database.IsUnknown() {
+		plan.Database = types.StringValue(r.client.userDatabase())
+	}
+	databaseName := plan.Database.ValueString()
+	username := plan.Username
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating user %s.%s", databaseName, username))
+
+	command := bson.D{{Key: "createUser", Value: username}}
+	if !plan.Password.IsNull() {
+		command = append(command, bson.E{Key: "pwd", Value: plan.Password.ValueString()})
+	}
+	command = append(command, bson.E{Key: "roles", Value: toMongoRoles(plan.Roles)})
+	if plan.Mechanisms != nil {
+		command = append(command, bson.E{Key: "mechanisms", Value: plan.Mechanisms})
+	}
+	if plan.AuthenticationRestrictions != nil {
+		command = append(command, bson.E{Key: "authenticationRestrictions", Value: toMongoRestrictions(plan.AuthenticationRestrictions)})
+	}
+
+	err = r.client.Database(databaseName).RunCommand(ctx, command).Err()
+	if isUserExists(err) {
+		// A user which already exists, e.g. created before the deployment was managed by Terraform, is adopted
+		// when it is defined as planned.
+		tflog.Debug(ctx, fmt.Sprintf("User %s.%s already exists, checking its definition", databaseName, username))
+		var found *userInfo
+		found, err = readUser(ctx, r.client, databaseName, username)
+		if err == nil && found == nil {
+			err = fmt.Errorf("user %s.%s was dropped while being adopted", databaseName, username)
+		}
+		var differences []string
+		if err == nil {
+			differences, err = userDifferences(plan, found)
+		}
+		if err == nil && len(differences) > 0 {
+			resp.Diagnostics.AddError(
+				"User already exists",
+				fmt.Sprintf("User %s.%s already exists with a different definition: %s. Please align the configuration "+
+					"with the existing user, or drop it.", databaseName, username, strings.Join(differences, "; ")),
+			)
+			return
+		}
+		// The password of the existing user cannot be compared, it is set to the planned one.
+		if err == nil && !plan.Password.IsNull() {
+			err = r.client.Database(databaseName).RunCommand(ctx, bson.D{
+				{Key: "updateUser", Value: username},
+				{Key: "pwd", Value: plan.Password.ValueString()},
+			}).Err()
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create user",
+			"An unexpected error occurred when creating user. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, username))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("User %s.%s created", databaseName, username))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state userResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := r.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database.ValueString()
+	username := state.Username
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading user %s.%s", databaseName, username))
+
+	found, err := readUser(ctx, r.client, databaseName, username)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read user",
+			"An unexpected error occurred when reading user. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	if found == nil {
+		tflog.Warn(ctx, fmt.Sprintf("User %s.%s not found, removing it from the state", databaseName, username))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Roles and restrictions left unset are read back as null while the user has none, and so are mechanisms
+	// while they are the default ones.
+	if state.Roles != nil || len(found.Roles) > 0 {
+		state.Roles = found.Roles
+	}
+	if state.Mechanisms != nil || !sameStrings(found.Mechanisms, defaultMechanisms) {
+		state.Mechanisms = found.Mechanisms
+	}
+	restrictions, err := fromMongoRestrictions(found.AuthenticationRestrictions)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse authentication restrictions",
+			"An unexpected error occurred when parsing the authentication restrictions of user. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	if state.AuthenticationRestrictions != nil || len(restrictions) > 0 {
+		state.AuthenticationRestrictions = restrictions
+	}
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, username))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read user %s.%s", databaseName, username))
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Changes to database and username result in resource recreation, the other attributes are updated in place.
+	var plan, state userResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := plan.Database.ValueString()
+	username := plan.Username
+	db := r.client.Database(databaseName)
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating user %s.%s", databaseName, username))
+
+	var commands []bson.D
+	if command := updateUserCommand(plan, state); command != nil {
+		commands = append(commands, command)
+	}
+	granted, revoked := diffRoles(state.Roles, plan.Roles)
+	if len(granted) > 0 {
+		commands = append(commands, bson.D{
+			{Key: "grantRolesToUser", Value: username},
+			{Key: "roles", Value: toMongoRoles(granted)},
+		})
+	}
+	if len(revoked) > 0 {
+		commands = append(commands, bson.D{
+			{Key: "revokeRolesFromUser", Value: username},
+			{Key: "roles", Value: toMongoRoles(revoked)},
+		})
+	}
+
+	for _, command := range commands {
+		err := db.RunCommand(ctx, command).Err()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update user",
+				fmt.Sprintf("An unexpected error occurred when running %s. ", command[0].Key)+
+					reportFooter()+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, username))
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("User %s.%s updated", databaseName, username))
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state userResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := state.Database.ValueString()
+	username := state.Username
+
+	tflog.Debug(ctx, fmt.Sprintf("Dropping user %s.%s", databaseName, username))
+
+	err = r.client.Database(databaseName).RunCommand(ctx, bson.D{{Key: "dropUser", Value: username}}).Err()
+	if err != nil && !isUserNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to drop user",
+			"An unexpected error occurred when dropping user. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Dropped user %s.%s", databaseName, username))
+}
+
+// ImportState imports an existing resource into Terraform state.
+func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Database names cannot contain dots, usernames can.
+	database, username, found := strings.Cut(req.ID, ".")
+	if !found || database == "" || username == "" {
+		resp.Diagnostics.AddError(
+			"Invalid id format. Should be <database>.<username>.",
+			fmt.Sprintf("Unable to import user %q, the id must be formatted as <database>.<username>.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), database)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), username)...)
+}
+
+// readUser reads the user defined in the database, nil if it does not exist.
+func readUser(ctx context.Context, client *mongodbClient, databaseName string, username string) (*userInfo, error) {
+	var result struct {
+		Users []userInfo `bson:"users"`
+	}
+	err := client.readDatabase(databaseName).RunCommand(ctx, bson.D{
+		{Key: "usersInfo", Value: bson.D{{Key: "user", Value: username}, {Key: "db", Value: databaseName}}},
+		{Key: "showAuthenticationRestrictions", Value: true},
+	}).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Users) == 0 {
+		return nil, nil
+	}
+	return &result.Users[0], nil
+}
+
+// updateUserCommand returns the updateUser command changing the password, mechanisms and restrictions of the user
+// to the planned ones, nil when they don't change. The password is sent along changed mechanisms, as the server
+// needs it to add a mechanism.
+func updateUserCommand(plan userResourceModel, state userResourceModel) bson.D {
+	command := bson.D{{Key: "updateUser", Value: plan.Username}}
+	mechanismsChanged := !sameStrings(userMechanisms(plan.Mechanisms), userMechanisms(state.Mechanisms))
+	if !plan.Password.IsNull() && (!plan.Password.Equal(state.Password) || mechanismsChanged) {
+		command = append(command, bson.E{Key: "pwd", Value: plan.Password.ValueString()})
+	}
+	if mechanismsChanged {
+		command = append(command, bson.E{Key: "mechanisms", Value: userMechanisms(plan.Mechanisms)})
+	}
+	if !restrictionsEqual(plan.AuthenticationRestrictions, state.AuthenticationRestrictions) {
+		command = append(command, bson.E{Key: "authenticationRestrictions", Value: toMongoRestrictions(plan.AuthenticationRestrictions)})
+	}
+	if len(command) == 1 {
+		return nil
+	}
+	return command
+}
+
+// userDifferences describes how the user found in the database differs from the planned one, the password aside,
+// nil when it is defined as planned.
+func userDifferences(plan userResourceModel, found *userInfo) ([]string, error) {
+	var differences []string
+	missing, unexpected := diffRoles(found.Roles, plan.Roles)
+	if len(unexpected) > 0 {
+		differences = append(differences, fmt.Sprintf("it is granted roles %s which are not configured", formatRoles(unexpected)))
+	}
+	if len(missing) > 0 {
+		differences = append(differences, fmt.Sprintf("it is not granted the configured roles %s", formatRoles(missing)))
+	}
+	if !sameStrings(found.Mechanisms, userMechanisms(plan.Mechanisms)) {
+		differences = append(differences, fmt.Sprintf("it has mechanisms %s instead of %s",
+			strings.Join(found.Mechanisms, ", "), strings.Join(userMechanisms(plan.Mechanisms), ", ")))
+	}
+	restrictions, err := fromMongoRestrictions(found.AuthenticationRestrictions)
+	if err != nil {
+		return nil, err
+	}
+	if !restrictionsEqual(restrictions, plan.AuthenticationRestrictions) {
+		differences = append(differences, fmt.Sprintf("it has authentication restrictions %s instead of %s",
+			formatRestrictions(restrictions), formatRestrictions(plan.AuthenticationRestrictions)))
+	}
+	return differences, nil
+}
+
+// formatRoles formats roles for diagnostics, as database.role.
+func formatRoles(roles []userRole) string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Db+"."+role.Role)
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatRestrictions formats authentication restrictions for diagnostics, as in the configuration.
+func formatRestrictions(restrictions []authenticationRestriction) string {
+	if len(restrictions) == 0 {
+		return "none"
+	}
+	formatted := make([]string, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		var fields []string
+		if restriction.ClientSource != nil {
+			fields = append(fields, fmt.Sprintf("client_source = [%s]", strings.Join(restriction.ClientSource, ", ")))
+		}
+		if restriction.ServerAddress != nil {
+			fields = append(fields, fmt.Sprintf("server_address = [%s]", strings.Join(restriction.ServerAddress, ", ")))
+		}
+		formatted = append(formatted, "{ "+strings.Join(fields, ", ")+" }")
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// userMechanisms returns the mechanisms of the user, the default ones when unset.
+func userMechanisms(mechanisms []string) []string {
+	if mechanisms == nil {
+		return defaultMechanisms
+	}
+	return mechanisms
+}
+
+// authenticationRestrictionsAttribute returns the schema of the authentication restrictions of users and roles.
+func authenticationRestrictionsAttribute(principal string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: fmt.Sprintf("Restrictions of the addresses the %s can authenticate from and to. "+
+			"Authentication succeeds when any restriction is met.", principal),
+		Optional: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"client_source": schema.SetAttribute{
+					Description: "IP addresses or CIDR ranges the client must connect from.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+				"server_address": schema.SetAttribute{
+					Description: "IP addresses or CIDR ranges of the server the client must connect to.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+			},
+		},
+	}
+}
+
+// toMongoRestrictions converts authentication restrictions into the documents expected by the user and role
+// commands, an empty array for none.
+func toMongoRestrictions(restrictions []authenticationRestriction) []authenticationRestriction {
+	if restrictions == nil {
+		return []authenticationRestriction{}
+	}
+	return restrictions
+}
+
+// fromMongoRestrictions converts the authentication restrictions returned by usersInfo and rolesInfo, documents
+// possibly nested in arrays, nil for none.
+func fromMongoRestrictions(value bson.RawValue) ([]authenticationRestriction, error) {
+	array, ok := value.ArrayOK()
+	if !ok {
+		return nil, nil
+	}
+	values, err := array.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	var restrictions []authenticationRestriction
+	for _, element := range values {
+		if _, nested := element.ArrayOK(); nested {
+			found, err := fromMongoRestrictions(element)
+			if err != nil {
+				return nil, err
+			}
+			restrictions = append(restrictions, found...)
+			continue
+		}
+		var restriction authenticationRestriction
+		if err = element.Unmarshal(&restriction); err != nil {
+			return nil, err
+		}
+		restrictions = append(restrictions, restriction)
+	}
+	return restrictions, nil
+}
+
+// restrictionsEqual checks whether two lists of authentication restrictions are the same, regardless of the
+// order of the addresses of each restriction.
+func restrictionsEqual(a []authenticationRestriction, b []authenticationRestriction) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameStrings(a[i].ClientSource, b[i].ClientSource) || !sameStrings(a[i].ServerAddress, b[i].ServerAddress) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameStrings checks whether two lists have the same strings, regardless of their order.
+func sameStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := slices.Clone(a)
+	sortedB := slices.Clone(b)
+	slices.Sort(sortedA)
+	slices.Sort(sortedB)
+	return slices.Equal(sortedA, sortedB)
+}
+
+// toMongoRoles converts roles into the documents expected by the user and role commands, an empty array for none.
+func toMongoRoles(roles []userRole) bson.A {
+	res := bson.A{}
+	for _, role := range roles {
+		res = append(res, bson.D{{Key: "role", Value: role.Role}, {Key: "db", Value: role.Db}})
+	}
+	return res
+}
+
+// diffRoles returns the roles to grant and to revoke to go from the current roles to the planned ones.
+func diffRoles(current []userRole, planned []userRole) (granted []userRole, revoked []userRole) {
+	currentSet := make(map[userRole]bool, len(current))
+	for _, role := range current {
+		currentSet[role] = true
+	}
+	plannedSet := make(map[userRole]bool, len(planned))
+	for _, role := range planned {
+		plannedSet[role] = true
+		if !currentSet[role] {
+			granted = append(granted, role)
+		}
+	}
+	for _, role := range current {
+		if !plannedSet[role] {
+			revoked = append(revoked, role)
+		}
+	}
+	return granted, revoked
+}
@@ -0,0 +1,498 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestDiffRoles(t *testing.T) {
+	read := userRole{Role: "read", Db: "shop"}
+	readWrite := userRole{Role: "readWrite", Db: "shop"}
+	reporting := userRole{Role: "read", Db: "reporting"}
+
+	granted, revoked := diffRoles([]userRole{read, reporting}, []userRole{readWrite, reporting})
+	if !reflect.DeepEqual(granted, []userRole{readWrite}) || !reflect.DeepEqual(revoked, []userRole{read}) {
+		t.Errorf("Expected to grant readWrite and revoke read, got %v and %v", granted, revoked)
+	}
+
+	granted, revoked = diffRoles([]userRole{read}, []userRole{read})
+	if len(granted) != 0 || len(revoked) != 0 {
+		t.Errorf("Expected no change, got %v and %v", granted, revoked)
+	}
+}
+
+func TestUpdateUserCommand(t *testing.T) {
+	state := userResourceModel{Username: "app", Password: types.StringValue("password")}
+
+	if command := updateUserCommand(state, state); command != nil {
+		t.Errorf("Expected no command without change, got %v", command)
+	}
+
+	// The password is sent along changed mechanisms.
+	plan := state
+	plan.Mechanisms = []string{"SCRAM-SHA-256"}
+	want := bson.D{
+		{Key: "updateUser", Value: "app"},
+		{Key: "pwd", Value: "password"},
+		{Key: "mechanisms", Value: []string{"SCRAM-SHA-256"}},
+	}
+	if command := updateUserCommand(plan, state); !reflect.DeepEqual(command, want) {
+		t.Errorf("Expected %v, got %v", want, command)
+	}
+
+	// Unset mechanisms are the default ones.
+	plan = state
+	plan.Mechanisms = []string{"SCRAM-SHA-256", "SCRAM-SHA-1"}
+	if command := updateUserCommand(plan, state); command != nil {
+		t.Errorf("Expected no command for the default mechanisms, got %v", command)
+	}
+
+	// Removed restrictions are sent as an empty array.
+	state.AuthenticationRestrictions = []authenticationRestriction{{ClientSource: []string{"10.0.0.0/8"}}}
+	plan = state
+	plan.AuthenticationRestrictions = nil
+	want = bson.D{
+		{Key: "updateUser", Value: "app"},
+		{Key: "authenticationRestrictions", Value: []authenticationRestriction{}},
+	}
+	if command := updateUserCommand(plan, state); !reflect.DeepEqual(command, want) {
+		t.Errorf("Expected %v, got %v", want, command)
+	}
+}
+
+func TestFromMongoRestrictions(t *testing.T) {
+	restriction := bson.D{{Key: "clientSource", Value: bson.A{"10.0.0.0/8"}}}
+	want := []authenticationRestriction{{ClientSource: []string{"10.0.0.0/8"}}}
+
+	for name, value := range map[string]interface{}{
+		"documents": bson.A{restriction},
+		"nested":    bson.A{bson.A{restriction}},
+	} {
+		raw, _ := bson.Marshal(bson.D{{Key: "authenticationRestrictions", Value: value}})
+		found, err := fromMongoRestrictions(bson.Raw(raw).Lookup("authenticationRestrictions"))
+		if err != nil || !reflect.DeepEqual(found, want) {
+			t.Errorf("%s: expected %v, got %v and %v", name, want, found, err)
+		}
+	}
+
+	if found, err := fromMongoRestrictions(bson.RawValue{}); found != nil || err != nil {
+		t.Errorf("Expected no restrictions, got %v and %v", found, err)
+	}
+}
+
+func TestUserDifferences(t *testing.T) {
+	found := &userInfo{
+		Roles:      []userRole{{Role: "read", Db: "shop"}},
+		Mechanisms: []string{"SCRAM-SHA-256", "SCRAM-SHA-1"},
+	}
+
+	plan := userResourceModel{Roles: []userRole{{Role: "read", Db: "shop"}}}
+	if differences, err := userDifferences(plan, found); differences != nil || err != nil {
+		t.Errorf("Expected no difference, got %v and %v", differences, err)
+	}
+
+	plan = userResourceModel{
+		Roles:                      []userRole{{Role: "readWrite", Db: "shop"}},
+		Mechanisms:                 []string{"SCRAM-SHA-256"},
+		AuthenticationRestrictions: []authenticationRestriction{{ClientSource: []string{"10.0.0.0/8"}}},
+	}
+	want := []string{
+		"it is granted roles shop.read which are not configured",
+		"it is not granted the configured roles shop.readWrite",
+		"it has mechanisms SCRAM-SHA-256, SCRAM-SHA-1 instead of SCRAM-SHA-256",
+		"it has authentication restrictions none instead of { client_source = [10.0.0.0/8] }",
+	}
+	if differences, err := userDifferences(plan, found); !reflect.DeepEqual(differences, want) || err != nil {
+		t.Errorf("Expected %v, got %v and %v", want, differences, err)
+	}
+}
+
+func TestMongodbClientUserDatabase(t *testing.T) {
+	if database := (&mongodbClient{}).userDatabase(); database != "admin" {
+		t.Errorf("Expected admin by default, got %s", database)
+	}
+	if database := (&mongodbClient{authDatabase: "users"}).userDatabase(); database != "users" {
+		t.Errorf("Expected the auth database, got %s", database)
+	}
+}
+
+func TestAccUserResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "app" {
+	database = "test_users"
+	username = "app"
+	password = "first-password"
+	roles = [
+		{ role = "read", db = "test_users" },
+		{ role = "read", db = "test_reporting" },
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.app", "database", "test_users"),
+					resource.TestCheckResourceAttr("mongodb_user.app", "roles.#", "2"),
+					testAccCheckUserRoles(t, "test_users", "app", userRole{Role: "read", Db: "test_users"}, userRole{Role: "read", Db: "test_reporting"}),
+					testAccCheckUserPassword(t, "test_users", "app", "first-password"),
+				),
+			},
+			{
+				ResourceName:            "mongodb_user.app",
+				ImportStateId:           "test_users.app",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			// Password and roles changes are done in place.
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "app" {
+	database = "test_users"
+	username = "app"
+	password = "second-password"
+	roles = [
+		{ role = "readWrite", db = "test_users" },
+		{ role = "read", db = "test_reporting" },
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_user.app", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckUserRoles(t, "test_users", "app", userRole{Role: "readWrite", Db: "test_users"}, userRole{Role: "read", Db: "test_reporting"}),
+					testAccCheckUserPassword(t, "test_users", "app", "second-password"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceDefaultDatabase(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "default" {
+	username = "test_default_database"
+	password = "password"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.default", "database", "admin"),
+					resource.TestCheckNoResourceAttr("mongodb_user.default", "roles"),
+					testAccCheckUserRoles(t, "admin", "test_default_database"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceAuthenticationRestrictions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "restricted" {
+	database = "test_users"
+	username = "restricted"
+	password = "password"
+	authentication_restrictions = [
+		{ client_source = ["127.0.0.1", "10.0.0.0/8"] },
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.restricted", "authentication_restrictions.#", "1"),
+					resource.TestCheckResourceAttr("mongodb_user.restricted", "authentication_restrictions.0.client_source.#", "2"),
+					testAccCheckUserRestrictions(t, "test_users", "restricted", authenticationRestriction{ClientSource: []string{"127.0.0.1", "10.0.0.0/8"}}),
+				),
+			},
+			// Removing the restrictions is done in place.
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "restricted" {
+	database = "test_users"
+	username = "restricted"
+	password = "password"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_user.restricted", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckUserRestrictions(t, "test_users", "restricted"),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceMechanisms(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "sha256" {
+	database = "test_users"
+	username = "sha256"
+	password = "password"
+	mechanisms = ["SCRAM-SHA-256"]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.sha256", "mechanisms.#", "1"),
+					resource.TestCheckTypeSetElemAttr("mongodb_user.sha256", "mechanisms.*", "SCRAM-SHA-256"),
+					testAccCheckUserMechanisms(t, "test_users", "sha256", "SCRAM-SHA-256"),
+					testAccCheckUserPassword(t, "test_users", "sha256", "password"),
+				),
+			},
+			// Adding a mechanism is done in place, along the password.
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "sha256" {
+	database = "test_users"
+	username = "sha256"
+	password = "password"
+	mechanisms = ["SCRAM-SHA-1", "SCRAM-SHA-256"]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_user.sha256", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckUserMechanisms(t, "test_users", "sha256", "SCRAM-SHA-1", "SCRAM-SHA-256"),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "sha256" {
+	database = "test_users"
+	username = "sha256"
+	password = "password"
+	mechanisms = ["SCRAM-MD5"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceImport(t *testing.T) {
+	config := providerConfig + `
+resource "mongodb_user" "imported" {
+	database = "test_users"
+	username = "imported"
+	mechanisms = ["SCRAM-SHA-256"]
+	authentication_restrictions = [
+		{ client_source = ["127.0.0.1", "10.0.0.0/8"] },
+	]
+	roles = [
+		{ role = "read", db = "test_users" },
+	]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			err := testAccClient(t).Database("test_users").RunCommand(context.Background(), bson.D{
+				{Key: "createUser", Value: "imported"},
+				{Key: "pwd", Value: "password"},
+				{Key: "mechanisms", Value: bson.A{"SCRAM-SHA-256"}},
+				{Key: "authenticationRestrictions", Value: bson.A{bson.D{{Key: "clientSource", Value: bson.A{"10.0.0.0/8", "127.0.0.1"}}}}},
+				{Key: "roles", Value: bson.A{bson.D{{Key: "role", Value: "read"}, {Key: "db", Value: "test_users"}}}},
+			}).Err()
+			if err != nil {
+				t.Fatalf("Unable to create user: %v", err)
+			}
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ResourceName:       "mongodb_user.imported",
+				ImportStateId:      "test_users.imported",
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			// The password aside, which is not configured here, the user is imported as configured.
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+// A user which already exists is adopted when it is defined as configured, and is an error otherwise.
+func TestAccUserResourceAdopt(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			db := testAccClient(t).Database("test_users")
+			for _, username := range []string{"adopted", "mismatched"} {
+				err := db.RunCommand(context.Background(), bson.D{
+					{Key: "createUser", Value: username},
+					{Key: "pwd", Value: "password"},
+					{Key: "roles", Value: bson.A{bson.D{{Key: "role", Value: "read"}, {Key: "db", Value: "test_users"}}}},
+				}).Err()
+				if err != nil {
+					t.Fatalf("Unable to create user: %v", err)
+				}
+			}
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			return testAccClient(t).Database("test_users").RunCommand(context.Background(), bson.D{{Key: "dropUser", Value: "mismatched"}}).Err()
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "mismatched" {
+	database = "test_users"
+	username = "mismatched"
+	password = "password"
+	roles = [
+		{ role = "readWrite", db = "test_users" },
+	]
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)already exists with a different definition:.*it is granted roles\s+test_users.read which are\s+not configured`),
+			},
+			{
+				Config: providerConfig + `
+resource "mongodb_user" "adopted" {
+	database = "test_users"
+	username = "adopted"
+	password = "new-password"
+	roles = [
+		{ role = "read", db = "test_users" },
+	]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_user.adopted", "id", "test_users.adopted"),
+					testAccCheckUserRoles(t, "test_users", "adopted", userRole{Role: "read", Db: "test_users"}),
+					testAccCheckUserPassword(t, "test_users", "adopted", "new-password"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckUserRoles checks the user exists in the database with exactly the roles.
+func testAccCheckUserRoles(t *testing.T, databaseName string, username string, roles ...userRole) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		found, err := readUser(context.Background(), &mongodbClient{Client: testAccClient(t)}, databaseName, username)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("user %s not found in database %s", username, databaseName)
+		}
+		granted, revoked := diffRoles(found.Roles, roles)
+		if len(granted) != 0 || len(revoked) != 0 {
+			return fmt.Errorf("expected roles %v, got %v", roles, found.Roles)
+		}
+		return nil
+	}
+}
+
+// testAccCheckUserPassword checks the user authenticates against its database with the password.
+func testAccCheckUserPassword(t *testing.T, databaseName string, username string, password string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		ctx := context.Background()
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://localhost:27017").SetAuth(options.Credential{
+			AuthSource: databaseName,
+			Username:   username,
+			Password:   password,
+		}))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = client.Disconnect(ctx) }()
+		return client.Database(databaseName).RunCommand(ctx, bson.D{{Key: "connectionStatus", Value: 1}}).Err()
+	}
+}
+
+// testAccCheckUserRestrictions checks the user exists in the database with exactly the authentication restrictions.
+func testAccCheckUserRestrictions(t *testing.T, databaseName string, username string, restrictions ...authenticationRestriction) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		found, err := readUser(context.Background(), &mongodbClient{Client: testAccClient(t)}, databaseName, username)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("user %s not found in database %s", username, databaseName)
+		}
+		foundRestrictions, err := fromMongoRestrictions(found.AuthenticationRestrictions)
+		if err != nil {
+			return err
+		}
+		if !restrictionsEqual(foundRestrictions, restrictions) {
+			return fmt.Errorf("expected authentication restrictions %v, got %v", restrictions, foundRestrictions)
+		}
+		return nil
+	}
+}
+
+// testAccCheckUserMechanisms checks the user exists in the database with exactly the SCRAM mechanisms.
+func testAccCheckUserMechanisms(t *testing.T, databaseName string, username string, mechanisms ...string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		found, err := readUser(context.Background(), &mongodbClient{Client: testAccClient(t)}, databaseName, username)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("user %s not found in database %s", username, databaseName)
+		}
+		if !sameStrings(found.Mechanisms, mechanisms) {
+			return fmt.Errorf("expected mechanisms %v, got %v", mechanisms, found.Mechanisms)
+		}
+		return nil
+	}
+}
@@ -1,16 +1,39 @@
 package provider
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"golang.org/x/net/proxy"
 )
 
+// providerVersion is the version of the provider, stamped in the default app name and in error diagnostics.
+var providerVersion = "dev"
+
+// reportFooter introduces the error in the details of unexpected error diagnostics, with the provider version
+// to report along with it.
+func reportFooter() string {
+	return "If the error is not clear, please contact the provider developers, mentioning provider version " + providerVersion + ".\n\n"
+}
+
+// defaultAppName is the app name the provider connections are made with, visible in the server logs and
+// the profiler, unless the url sets one.
+func defaultAppName() string {
+	return "terraform-provider-mongodb/" + providerVersion
+}
+
 // Convert an index type declared in terraform as string into a type and value expected by Mongo's client.
 func convertToMongoIndexType(indexType string) interface{} {
 	switch indexType {
@@ -45,6 +68,111 @@ func convertToTfIndexType(indexType interface{}) (string, error) {
 	return "", errors.New("typeIndex MUST be int32 or string")
 }
 
+// Convert the index keys declared in terraform into an ordered document expected by Mongo's client.
+func toMongoIndexKeys(keys []indexKey) bson.D {
+	res := bson.D{}
+	for _, key := range keys {
+		res = append(res, bson.E{Key: key.Field, Value: convertToMongoIndexType(key.Type)})
+	}
+	return res
+}
+
+// Convert the keys document of an index returned by Mongo's client into index keys understood by terraform.
+func toTfIndexKeys(keysDocument bson.Raw) ([]indexKey, error) {
+	var foundKeys bson.D
+	err := bson.Unmarshal(keysDocument, &foundKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]indexKey, 0)
+	for _, v := range foundKeys {
+		typ, err := convertToTfIndexType(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, indexKey{Field: v.Key, Type: typ})
+	}
+	return res, nil
+}
+
+// sameIndexKeys checks whether two lists of keys declare the same index. The order of the text fields of text
+// indexes does not matter, the server keeping them in its own order.
+func sameIndexKeys(a []indexKey, b []indexKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	textFields := make(map[string]int)
+	for i := range a {
+		if a[i].Type == "text" && b[i].Type == "text" {
+			textFields[a[i].Field]++
+			textFields[b[i].Field]--
+		} else if a[i] != b[i] {
+			return false
+		}
+	}
+	for _, count := range textFields {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Check whether the error returned by the server is an IndexNotFound error.
+func isIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == 27
+}
+
+// Check whether the error returned by the server is a NamespaceNotFound error.
+func isNamespaceNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == 26
+}
+
+// Check whether the error returned by the server is an Unauthorized error.
+func isUnauthorized(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == 13
+}
+
+// Check whether the error returned by the server is a UserNotFound error.
+func isUserNotFound(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(11)
+}
+
+// Check whether the error returned by the server is a RoleNotFound error.
+func isRoleNotFound(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(31)
+}
+
+// Check whether the error returned by the server is a user already exists error.
+func isUserExists(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(51003)
+}
+
+// Check whether the error returned by the server is a role already exists error.
+func isRoleExists(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(51002)
+}
+
+// Check whether the error returned by the server is a MaxTimeMSExpired error, i.e. the command exceeded its maxTimeMS.
+func isMaxTimeExpired(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(50)
+}
+
+// Check whether the error returned by the server is a write concern timeout.
+func isWriteConcernTimeout(err error) bool {
+	var writeErr mongo.WriteException
+	return errors.As(err, &writeErr) && writeErr.WriteConcernError != nil && writeErr.WriteConcernError.Code == 64
+}
+
 type indexId struct {
 	database   string
 	collection string
@@ -95,6 +223,45 @@ func (co *collation) toMongoCollation() *options.Collation {
 	return &res
 }
 
+// mongoCollation maps the collation document of an index returned by Mongo's client.
+type mongoCollation struct {
+	Locale          string  `bson:"locale"`
+	CaseLevel       *bool   `bson:"caseLevel"`
+	CaseFirst       *string `bson:"caseFirst"`
+	Strength        *int    `bson:"strength"`
+	NumericOrdering *bool   `bson:"numericOrdering"`
+	Alternate       *string `bson:"alternate"`
+	MaxVariable     *string `bson:"maxVariable"`
+	Normalization   *bool   `bson:"normalization"`
+	Backwards       *bool   `bson:"backwards"`
+}
+
+// Convert the collation document returned by Mongo's client into a collation understood by terraform,
+// nil when the document is empty.
+func fromMongoCollation(collationDocument bson.Raw) (*collation, error) {
+	if len(collationDocument) == 0 {
+		return nil, nil
+	}
+
+	var found mongoCollation
+	err := bson.Unmarshal(collationDocument, &found)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collation{
+		Locale:          found.Locale,
+		CaseLevel:       found.CaseLevel,
+		CaseFirst:       found.CaseFirst,
+		Strength:        found.Strength,
+		NumericOrdering: found.NumericOrdering,
+		Alternate:       found.Alternate,
+		MaxVariable:     found.MaxVariable,
+		Normalization:   found.Normalization,
+		Backwards:       found.Backwards,
+	}, nil
+}
+
 func addArgs(arguments string, newArg string) string {
 	if arguments != "" {
 		return arguments + "&" + newArg
@@ -127,7 +294,10 @@ func getTLSConfigWithAllServerCertificates(
 	}
 
 	// --- Handle client certificate (optional) ---
-	if len(certPEM) > 0 && len(keyPEM) > 0 {
+	if (len(certPEM) > 0) != (len(keyPEM) > 0) {
+		return nil, errors.New("client certificate and private key must be set together")
+	}
+	if len(certPEM) > 0 {
 		cert, err := tls.X509KeyPair(certPEM, keyPEM)
 		if err != nil {
 			return nil, err
@@ -138,12 +308,17 @@ func getTLSConfigWithAllServerCertificates(
 	return tlsConfig, nil
 }
 
-func proxyDialer(proxyUrlFromProvider string) (options.ContextDialer, error) {
+// Create the dialer through the proxy, nil without proxy. The certificate of https proxies is verified
+// against proxyCaPEM, separately from the certificate of the MongoDB server.
+func proxyDialer(proxyUrlFromProvider string, proxyCaPEM []byte) (options.ContextDialer, error) {
 	if proxyUrlFromProvider != "" {
 		proxyURL, err := url.Parse(proxyUrlFromProvider)
 		if err != nil {
 			return nil, err
 		}
+		if proxyURL.Scheme == "http" || proxyURL.Scheme == "https" {
+			return newHTTPConnectDialer(proxyURL, proxyCaPEM)
+		}
 		proxyDialer, err := proxy.FromURL(proxyURL, proxy.Direct)
 		if err != nil {
 			return nil, err
@@ -154,3 +329,236 @@ func proxyDialer(proxyUrlFromProvider string) (options.ContextDialer, error) {
 
 	return nil, nil
 }
+
+// Get the version of the connected server as returned by buildInfo, e.g. [7 0 2 0].
+func serverVersion(ctx context.Context, client *mongo.Client) ([]int32, error) {
+	var buildInfo struct {
+		VersionArray []int32 `bson:"versionArray"`
+	}
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&buildInfo)
+	if err != nil {
+		return nil, err
+	}
+	if len(buildInfo.VersionArray) < 2 {
+		return nil, fmt.Errorf("unexpected server version %v", buildInfo.VersionArray)
+	}
+	return buildInfo.VersionArray, nil
+}
+
+// Query options of a connection URI holding secrets.
+var sensitiveURIOptions = map[string]bool{
+	"authmechanismproperties":         true,
+	"tlscertificatekeyfilepassword":   true,
+	"sslpemkeypassword":               true,
+	"sslclientcertificatekeypassword": true,
+}
+
+// Redact the password and secret options of a connection URI. When username is set, the credentials
+// are not part of the URI but set on the client, and are added to the URI with a redacted password.
+func redactConnectionURI(uri string, username string) string {
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		return "****"
+	}
+
+	authority, query := rest, ""
+	if i := strings.IndexAny(rest, "/?"); i >= 0 {
+		authority, query = rest[:i], rest[i:]
+	}
+
+	if at := strings.LastIndex(authority, "@"); at >= 0 {
+		user, _, hasPassword := strings.Cut(authority[:at], ":")
+		if hasPassword {
+			user += ":****"
+		}
+		authority = user + "@" + authority[at+1:]
+	} else if username != "" {
+		authority = url.User(username).String() + ":****@" + authority
+	}
+
+	if database, uriOptions, hasOptions := strings.Cut(query, "?"); hasOptions {
+		redacted := strings.Split(uriOptions, "&")
+		for i, option := range redacted {
+			if key, _, _ := strings.Cut(option, "="); sensitiveURIOptions[strings.ToLower(key)] {
+				redacted[i] = key + "=****"
+			}
+		}
+		query = database + "?" + strings.Join(redacted, "&")
+	}
+
+	return scheme + "://" + authority + query
+}
+
+// Build the connection URI equivalent to the client options, as the client connects with them whether they
+// come from the url or from provider attributes. Passwords and secret options are redacted as ****. Options
+// without URI equivalent, i.e. certificates given as PEM content, the proxy and the stable API, are left out.
+func effectiveConnectionURI(opts *options.ClientOptions) string {
+	var userinfo string
+	var query []string
+	addOption := func(key string, value string) {
+		query = append(query, key+"="+url.QueryEscape(value))
+	}
+
+	if auth := opts.Auth; auth != nil {
+		if auth.Username != "" {
+			userinfo = url.User(auth.Username).String()
+			if auth.Password != "" || auth.PasswordSet {
+				userinfo += ":****"
+			}
+			userinfo += "@"
+		}
+		if auth.AuthSource != "" {
+			addOption("authSource", auth.AuthSource)
+		}
+		if auth.AuthMechanism != "" {
+			addOption("authMechanism", auth.AuthMechanism)
+		}
+		if len(auth.AuthMechanismProperties) != 0 {
+			query = append(query, "authMechanismProperties=****")
+		}
+	}
+	if opts.ReplicaSet != nil {
+		addOption("replicaSet", *opts.ReplicaSet)
+	}
+	if opts.Direct != nil {
+		addOption("directConnection", strconv.FormatBool(*opts.Direct))
+	}
+	if opts.LoadBalanced != nil {
+		addOption("loadBalanced", strconv.FormatBool(*opts.LoadBalanced))
+	}
+	if opts.TLSConfig != nil {
+		addOption("tls", "true")
+		if opts.TLSConfig.InsecureSkipVerify {
+			addOption("tlsInsecure", "true")
+		}
+	}
+	if opts.RetryWrites != nil {
+		addOption("retryWrites", strconv.FormatBool(*opts.RetryWrites))
+	}
+	if opts.RetryReads != nil {
+		addOption("retryReads", strconv.FormatBool(*opts.RetryReads))
+	}
+	if opts.ReadPreference != nil {
+		addOption("readPreference", opts.ReadPreference.Mode().String())
+	}
+	if opts.ReadConcern != nil && opts.ReadConcern.Level != "" {
+		addOption("readConcernLevel", opts.ReadConcern.Level)
+	}
+	if opts.WriteConcern != nil && opts.WriteConcern.W != nil {
+		addOption("w", fmt.Sprint(opts.WriteConcern.W))
+	}
+	if opts.AppName != nil {
+		addOption("appName", *opts.AppName)
+	}
+	if len(opts.Compressors) != 0 {
+		addOption("compressors", strings.Join(opts.Compressors, ","))
+	}
+	if opts.MaxPoolSize != nil {
+		addOption("maxPoolSize", strconv.FormatUint(*opts.MaxPoolSize, 10))
+	}
+	if opts.MinPoolSize != nil {
+		addOption("minPoolSize", strconv.FormatUint(*opts.MinPoolSize, 10))
+	}
+	if opts.ConnectTimeout != nil {
+		addOption("connectTimeoutMS", strconv.FormatInt(opts.ConnectTimeout.Milliseconds(), 10))
+	}
+	if opts.ServerSelectionTimeout != nil {
+		addOption("serverSelectionTimeoutMS", strconv.FormatInt(opts.ServerSelectionTimeout.Milliseconds(), 10))
+	}
+
+	uri := "mongodb://" + userinfo + strings.Join(opts.Hosts, ",") + "/"
+	if len(query) != 0 {
+		uri += "?" + strings.Join(query, "&")
+	}
+	return uri
+}
+
+// serverInfo is the description of the connected server returned by hello.
+type serverInfo struct {
+	MaxWireVersion int32  `bson:"maxWireVersion"`
+	SetName        string `bson:"setName"`
+	Msg            string `bson:"msg"`
+}
+
+// Wire version of MongoDB 5.0, the first version supporting the stable API.
+const stableAPIWireVersion = 13
+
+// Describe the connected server with a single hello.
+func probeServer(ctx context.Context, client *mongo.Client) (*serverInfo, error) {
+	var hello serverInfo
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return nil, err
+	}
+	return &hello, nil
+}
+
+// Check whether the client is connected to a mongos of a sharded cluster.
+func isShardedCluster(ctx context.Context, client *mongo.Client) (bool, error) {
+	server, err := probeServer(ctx, client)
+	if err != nil {
+		return false, err
+	}
+	return server.Msg == "isdbgrid", nil
+}
+
+// Check whether the client is connected to a member of a replica set.
+func isReplicaSet(ctx context.Context, client *mongo.Client) (bool, error) {
+	server, err := probeServer(ctx, client)
+	if err != nil {
+		return false, err
+	}
+	return server.SetName != "", nil
+}
+
+func versionAtLeast(version []int32, major int32, minor int32) bool {
+	if len(version) < 2 {
+		return false
+	}
+	if version[0] != major {
+		return version[0] > major
+	}
+	return version[1] >= minor
+}
+
+// Get the stable API options to use, nil when the stable API is disabled or not supported by the server.
+// An unknown (nil) server keeps the stable API enabled.
+func stableAPIOptions(stableAPI types.Bool, server *serverInfo) *options.ServerAPIOptions {
+	if !stableAPI.IsNull() && !stableAPI.ValueBool() {
+		return nil
+	}
+	if server != nil && server.MaxWireVersion < stableAPIWireVersion {
+		return nil
+	}
+	return options.ServerAPI(options.ServerAPIVersion1)
+}
+
+func formatVersion(version []int32) string {
+	parts := make([]string, 0, len(version))
+	for _, v := range version {
+		parts = append(parts, strconv.Itoa(int(v)))
+	}
+	return strings.Join(parts, ".")
+}
+
+// withTimeout returns a context bounded by the timeout configured for a resource operation, unbounded when no
+// timeout is configured.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// checkTimeout reports the operation which exceeded its configured timeout, when it failed because of it.
+// operation is the name of the timeout, e.g. create, and target the resource, e.g. "index db.coll.name".
+func checkTimeout(ctx context.Context, diags *diag.Diagnostics, operation string, target string, timeout time.Duration) {
+	if !diags.HasError() || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+	diags.AddError(
+		"Timeout exceeded",
+		fmt.Sprintf("The %s operation on %s did not complete within the configured %s timeout of %s. "+
+			"Increase the %s timeout in the timeouts block of the resource.", operation, target, operation, timeout, operation),
+	)
+}
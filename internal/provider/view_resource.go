@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &viewResource{}
+	_ resource.ResourceWithConfigure   = &viewResource{}
+	_ resource.ResourceWithImportState = &viewResource{}
+)
+
+// viewResource is the resource implementation.
+type viewResource struct {
+	client *mongodbClient
+}
+
+// viewResourceModel maps the resource schema data.
+type viewResourceModel struct {
+	Database string       `tfsdk:"database"`
+	Name     string       `tfsdk:"name"`
+	ViewOn   string       `tfsdk:"view_on"`
+	Pipeline string       `tfsdk:"pipeline"`
+	Id       types.String `tfsdk:"id"`
+}
+
+// NewViewResource is a helper function to simplify the provider implementation.
+func NewViewResource() resource.Resource {
+	return &viewResource{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *viewResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring MongoDB view resource")
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongodbClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongodbClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+	tflog.Info(ctx, "Configured MongoDB view resource")
+}
+
+// Metadata returns the resource type name.
+func (r *viewResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_view"
+}
+
+// Schema defines the schema for the resource.
+func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create read-only views in MongoDB, the result of an aggregation pipeline on a collection or another view.",
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Description: "Name of the database where to create the view.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the view to create.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"view_on": schema.StringAttribute{
+				Description: "Name of the source collection or view, in the same database. Can be changed without recreating the view.",
+				Required:    true,
+			},
+			"pipeline": schema.StringAttribute{
+				Description: "Aggregation pipeline of the view, as a JSON array of stages in MongoDB Extended JSON, " +
+					"e.g. `[{\"$match\": {\"status\": \"active\"}}]`. Can be changed without recreating the view.",
+				Required: true,
+			},
+			"id": schema.StringAttribute{
+				Computed:           true,
+				DeprecationMessage: "Just there for compatibility reasons",
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *viewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan viewResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := plan.Database
+	viewName := plan.Name
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating view %s.%s on %s", databaseName, viewName, plan.ViewOn))
+
+	pipeline, err := parsePipeline(plan.Pipeline)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pipeline"),
+			"Invalid pipeline",
+			"The pipeline must be an array of stages in MongoDB Extended JSON.\n\nError: "+err.Error(),
+		)
+		return
+	}
+
+	r.client.checkDatabase(ctx, databaseName, resp.Diagnostics.AddError)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = r.client.ddlDatabase(databaseName).CreateView(ctx, viewName, plan.ViewOn, pipeline)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create view",
+			"An unexpected error occurred when creating view. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, viewName))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("View %s.%s created", databaseName, viewName))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *viewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state viewResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release := r.client.withSession(ctx)
+	defer release()
+
+	databaseName := state.Database
+	viewName := state.Name
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading view %s.%s", databaseName, viewName))
+
+	foundOptions, err := readCollectionOptions(ctx, r.client.readDatabase(databaseName), viewName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list collections",
+			"An unexpected error occurred when listing collections. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	if foundOptions == nil {
+		tflog.Warn(ctx, fmt.Sprintf("View %s.%s not found, removing it from the state", databaseName, viewName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if foundOptions.Type != "view" {
+		resp.Diagnostics.AddError(
+			"Not a view",
+			fmt.Sprintf("%s.%s is a %s, not a view", databaseName, viewName, foundOptions.Type),
+		)
+		return
+	}
+
+	state.ViewOn = foundOptions.ViewOn
+	state.Pipeline, err = foundOptions.toPipeline(state.Pipeline)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse view pipeline",
+			"An unexpected error occurred when parsing the view pipeline. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	state.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, viewName))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Read view %s.%s", databaseName, viewName))
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *viewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan viewResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := plan.Database
+	viewName := plan.Name
+
+	pipeline, err := parsePipeline(plan.Pipeline)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pipeline"),
+			"Invalid pipeline",
+			"The pipeline must be an array of stages in MongoDB Extended JSON.\n\nError: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating view %s.%s on %s", databaseName, viewName, plan.ViewOn))
+
+	// collMod requires both the source and the pipeline of a view, even when only one changes.
+	err = r.client.ddlDatabase(databaseName).RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: viewName},
+		{Key: "viewOn", Value: plan.ViewOn},
+		{Key: "pipeline", Value: pipeline},
+	}).Err()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update view",
+			"An unexpected error occurred when updating view. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s.%s", databaseName, viewName))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("View %s.%s updated", databaseName, viewName))
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *viewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state viewResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, release, err := r.client.withOperation(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to start operation",
+			"The operation did not start while waiting for other operations to complete, as limited by max_concurrent_operations. "+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	defer release()
+
+	databaseName := state.Database
+	viewName := state.Name
+
+	tflog.Debug(ctx, fmt.Sprintf("Dropping view %s.%s", databaseName, viewName))
+
+	err = r.client.Database(databaseName).Collection(viewName).Drop(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to drop view",
+			"An unexpected error occurred when dropping view. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Dropped view %s.%s", databaseName, viewName))
+}
+
+// ImportState imports an existing resource into Terraform state.
+func (r *viewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseCollectionId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid id format. Should be <database>.<view>.",
+			"An unexpected error occurred when importing view. "+
+				reportFooter()+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), id.database)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.collection)...)
+}
+
+// parsePipeline parses an aggregation pipeline written in MongoDB Extended JSON, either relaxed or canonical.
+func parsePipeline(pipeline string) (bson.A, error) {
+	// Extended JSON can only be parsed as a document, the array is parsed as its single field.
+	var document struct {
+		Pipeline bson.A `bson:"pipeline"`
+	}
+	err := bson.UnmarshalExtJSON([]byte(`{"pipeline": `+pipeline+`}`), false, &document)
+	if err != nil {
+		return nil, err
+	}
+	if document.Pipeline == nil {
+		return nil, fmt.Errorf("expected an array of stages, got %s", pipeline)
+	}
+	return document.Pipeline, nil
+}
+
+// toPipeline converts the view pipeline into canonical Extended JSON. The current pipeline is kept while it is
+// equivalent, so that the formatting of the configuration is not replaced.
+func (o *collectionOptions) toPipeline(current string) (string, error) {
+	array, ok := o.Pipeline.ArrayOK()
+	if !ok {
+		return "", fmt.Errorf("unexpected pipeline %s", o.Pipeline)
+	}
+	found, err := bson.Marshal(bson.D{{Key: "pipeline", Value: o.Pipeline}})
+	if err != nil {
+		return "", err
+	}
+	if pipeline, err := parsePipeline(current); err == nil {
+		raw, err := bson.Marshal(bson.D{{Key: "pipeline", Value: pipeline}})
+		if err == nil && bytes.Equal(raw, found) {
+			return current, nil
+		}
+	}
+
+	stages, err := array.Values()
+	if err != nil {
+		return "", err
+	}
+	converted := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		document, ok := stage.DocumentOK()
+		if !ok {
+			return "", fmt.Errorf("unexpected pipeline stage %s", stage)
+		}
+		// Canonical Extended JSON keeps the BSON types of the values, for the pipeline to be parsed back the same.
+		json, err := bson.MarshalExtJSON(document, true, false)
+		if err != nil {
+			return "", err
+		}
+		converted = append(converted, string(json))
+	}
+	return "[" + strings.Join(converted, ",") + "]", nil
+}
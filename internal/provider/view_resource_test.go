@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParsePipeline(t *testing.T) {
+	pipeline, err := parsePipeline(`[{"$match": {"count": {"$gte": {"$numberLong": "1"}}}}, {"$limit": 5}]`)
+	if err != nil {
+		t.Fatalf("Unable to parse pipeline: %v", err)
+	}
+	if len(pipeline) != 2 {
+		t.Fatalf("Expected 2 stages, got %v", pipeline)
+	}
+	raw, _ := bson.Marshal(pipeline[0])
+	if value := bson.Raw(raw).Lookup("$match", "count", "$gte"); value.Type != bson.TypeInt64 {
+		t.Errorf("Expected the long to be kept, got %s", value.Type)
+	}
+
+	for _, invalid := range []string{`{"$match": {}}`, `[{"$match": `, `null`} {
+		if _, err := parsePipeline(invalid); err == nil {
+			t.Errorf("Expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestCollectionOptionsPipeline(t *testing.T) {
+	raw, _ := bson.Marshal(bson.D{
+		{Key: "viewOn", Value: "orders"},
+		{Key: "pipeline", Value: bson.A{bson.D{{Key: "$match", Value: bson.D{{Key: "count", Value: int64(1)}}}}}},
+	})
+	var opts collectionOptions
+	if err := bson.Unmarshal(raw, &opts); err != nil {
+		t.Fatalf("Unable to parse options: %v", err)
+	}
+	if opts.ViewOn != "orders" {
+		t.Errorf("Expected the view to be on orders, got %q", opts.ViewOn)
+	}
+
+	// The configured pipeline is kept while equivalent.
+	current := `[ { "$match": { "count": { "$numberLong": "1" } } } ]`
+	if found, err := opts.toPipeline(current); err != nil || found != current {
+		t.Errorf("Expected the current pipeline to be kept, got %s and %v", found, err)
+	}
+
+	// A pipeline changed outside Terraform is read in canonical Extended JSON.
+	found, err := opts.toPipeline(`[{"$match": {"count": 2}}]`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := `[{"$match":{"count":{"$numberLong":"1"}}}]`; found != want {
+		t.Errorf("Expected %s, got %s", want, found)
+	}
+}
+
+func TestAccViewResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "orders" {
+	database = "test_views"
+	name = "orders"
+}
+
+resource "mongodb_view" "active_orders" {
+	database = "test_views"
+	name = "active_orders"
+	view_on = mongodb_collection.orders.name
+	pipeline = "[{\"$match\": {\"status\": \"active\"}}]"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_view.active_orders", "view_on", "orders"),
+					resource.TestCheckResourceAttr("mongodb_view.active_orders", "pipeline", `[{"$match": {"status": "active"}}]`),
+					resource.TestCheckResourceAttr("mongodb_view.active_orders", "id", "test_views.active_orders"),
+				),
+			},
+			{
+				ResourceName:      "mongodb_view.active_orders",
+				ImportStateId:     "test_views.active_orders",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The pipeline is imported in canonical Extended JSON, without the spaces of the configuration.
+				ImportStateVerifyIgnore: []string{"pipeline"},
+			},
+			// The pipeline is changed in place.
+			{
+				Config: providerConfig + `
+resource "mongodb_collection" "orders" {
+	database = "test_views"
+	name = "orders"
+}
+
+resource "mongodb_view" "active_orders" {
+	database = "test_views"
+	name = "active_orders"
+	view_on = mongodb_collection.orders.name
+	pipeline = "[{\"$match\": {\"status\": \"active\"}}, {\"$limit\": 10}]"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("mongodb_view.active_orders", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mongodb_view.active_orders", "pipeline", `[{"$match": {"status": "active"}}, {"$limit": 10}]`),
+				),
+			},
+		},
+	})
+}
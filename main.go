@@ -42,6 +42,8 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	provider.CloseClients(context.Background())
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}